@@ -1,8 +1,13 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"math/rand/v2"
 	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 )
@@ -86,6 +91,110 @@ func memoize(fn func(int) int) func(int) int {
 	}
 }
 
+// Memoize is the generic form of memoize: it wraps fn so each distinct key is
+// computed once and later calls return the cached result. The cache grows
+// without bound and is not safe for concurrent use.
+func Memoize[K comparable, V any](fn func(K) V) func(K) V {
+	cache := make(map[K]V)
+	return func(key K) V {
+		if val, exists := cache[key]; exists {
+			return val
+		}
+		result := fn(key)
+		cache[key] = result
+		return result
+	}
+}
+
+// MemoizeTTL is like Memoize, but each cached result expires ttl after it was
+// computed and the next call for that key recomputes it.
+func MemoizeTTL[K comparable, V any](fn func(K) V, ttl time.Duration) func(K) V {
+	return memoizeTTLWithClock(fn, ttl, time.Now)
+}
+
+// memoizeTTLWithClock implements MemoizeTTL using now as its time source.
+func memoizeTTLWithClock[K comparable, V any](fn func(K) V, ttl time.Duration, now func() time.Time) func(K) V {
+	type entry struct {
+		value     V
+		expiresAt time.Time
+	}
+
+	cache := make(map[K]entry)
+	return func(key K) V {
+		current := now()
+		if e, exists := cache[key]; exists && current.Before(e.expiresAt) {
+			return e.value
+		}
+		result := fn(key)
+		cache[key] = entry{value: result, expiresAt: current.Add(ttl)}
+		return result
+	}
+}
+
+// MemoizeSync is like Memoize but safe for concurrent use. Concurrent callers
+// for the same key share a single call to fn instead of each computing it.
+// If fn panics, nothing is cached and waiting callers retry the computation.
+func MemoizeSync[K comparable, V any](fn func(K) V) func(K) V {
+	type call struct {
+		done  chan struct{} // Closed once value is set or fn panicked
+		value V
+		ok    bool
+	}
+
+	var mu sync.Mutex
+	calls := make(map[K]*call)
+
+	return func(key K) V {
+		for {
+			mu.Lock()
+			if c, exists := calls[key]; exists {
+				mu.Unlock()
+				<-c.done
+				if c.ok {
+					return c.value
+				}
+				continue
+			}
+			c := &call{done: make(chan struct{})}
+			calls[key] = c
+			mu.Unlock()
+
+			func() {
+				defer func() {
+					if !c.ok {
+						mu.Lock()
+						delete(calls, key)
+						mu.Unlock()
+					}
+					close(c.done)
+				}()
+				c.value = fn(key)
+				c.ok = true
+			}()
+			return c.value
+		}
+	}
+}
+
+// CacheAside returns cache[key] if present; otherwise it calls load, stores the
+// result in cache, and returns it. Unlike memoize, load may fail: errors are
+// returned to the caller and nothing is cached, so the next call retries.
+// The caller owns cache, and CacheAside is not internally synchronized;
+// guard concurrent use with a mutex.
+func CacheAside[K comparable, V any](cache map[K]V, key K, load func(K) (V, error)) (V, error) {
+	if val, exists := cache[key]; exists {
+		return val, nil
+	}
+
+	val, err := load(key)
+	if err != nil {
+		var zero V
+		return zero, err
+	}
+	cache[key] = val
+	return val, nil
+}
+
 // fibonacciRecursive is an intentionally inefficient recursive implementation
 // used to demonstrate the performance benefits of memoization.
 func fibonacciRecursive(n int) int {
@@ -96,24 +205,68 @@ func fibonacciRecursive(n int) int {
 }
 
 // createEventEmitter implements the observer pattern using closures.
-// Returns functions to register event handlers and emit events.
-func createEventEmitter() (on func(string, func()), emit func(string)) {
-	listeners := make(map[string][]func())
+// Returns functions to register event handlers, emit events, and remove handlers.
+// on returns a subscription id that off uses to detach that handler.
+func createEventEmitter() (on func(string, func()) int, emit func(string), off func(string, int)) {
+	type listener struct {
+		id      int
+		handler func()
+	}
 
-	on = func(event string, handler func()) {
-		listeners[event] = append(listeners[event], handler)
-		fmt.Printf("Registered handler for event '%s'\n", event)
+	listeners := make(map[string][]listener)
+	nextID := 0
+
+	on = func(event string, handler func()) int {
+		nextID++
+		listeners[event] = append(listeners[event], listener{id: nextID, handler: handler})
+		fmt.Printf("Registered handler %d for event '%s'\n", nextID, event)
+		return nextID
 	}
 
 	emit = func(event string) {
 		if handlers, exists := listeners[event]; exists {
 			fmt.Printf("Emitting event '%s' to %d handlers\n", event, len(handlers))
-			for _, handler := range handlers {
-				handler()
+			for _, l := range handlers {
+				l.handler()
+			}
+		}
+	}
+
+	off = func(event string, id int) {
+		handlers := listeners[event]
+		for i, l := range handlers {
+			if l.id == id {
+				// Build a new slice so an emit in progress keeps its snapshot
+				remaining := append(handlers[:i:i], handlers[i+1:]...)
+				if len(remaining) == 0 {
+					delete(listeners, event)
+				} else {
+					listeners[event] = remaining
+				}
+				fmt.Printf("Removed handler %d for event '%s'\n", id, event)
+				return
 			}
 		}
 	}
 
+	return on, emit, off
+}
+
+// CreateTypedEmitter is like createEventEmitter, but handlers receive the
+// payload passed to emit. Handlers run in registration order.
+func CreateTypedEmitter[T any]() (on func(string, func(T)), emit func(string, T)) {
+	listeners := make(map[string][]func(T))
+
+	on = func(event string, handler func(T)) {
+		listeners[event] = append(listeners[event], handler)
+	}
+
+	emit = func(event string, payload T) {
+		for _, handler := range listeners[event] {
+			handler(payload)
+		}
+	}
+
 	return on, emit
 }
 
@@ -158,9 +311,14 @@ func primeGenerator() func() int {
 // QueryBuilder demonstrates the builder pattern with fluent interface.
 // Uses method chaining to construct SQL-like queries.
 type QueryBuilder struct {
-	table  string
-	wheres []string
-	limit  int
+	table    string
+	joins    []string
+	wheres   []string
+	args     []interface{} // Values bound to the ? placeholders in wheres, in order
+	groupBys []string
+	orderBys []string
+	limit    int
+	offset   int
 }
 
 func createQueryBuilder() func(string) *QueryBuilder {
@@ -175,26 +333,69 @@ func (qb *QueryBuilder) Where(condition string) *QueryBuilder {
 	return qb
 }
 
+// WhereEq adds a "column = ?" condition with value bound as a query argument,
+// keeping it out of the SQL text. Prefer it over Where for untrusted input.
+func (qb *QueryBuilder) WhereEq(column string, value interface{}) *QueryBuilder {
+	qb.wheres = append(qb.wheres, column+" = ?")
+	qb.args = append(qb.args, value)
+	return qb
+}
+
+// Join adds an inner join against table using the on condition.
+func (qb *QueryBuilder) Join(table, on string) *QueryBuilder {
+	qb.joins = append(qb.joins, fmt.Sprintf("JOIN %s ON %s", table, on))
+	return qb
+}
+
+// GroupBy adds columns to the GROUP BY clause.
+func (qb *QueryBuilder) GroupBy(cols ...string) *QueryBuilder {
+	qb.groupBys = append(qb.groupBys, cols...)
+	return qb
+}
+
+// OrderBy adds a sort column; repeated calls sort by each column in turn.
+func (qb *QueryBuilder) OrderBy(col string, desc bool) *QueryBuilder {
+	if desc {
+		col += " DESC"
+	}
+	qb.orderBys = append(qb.orderBys, col)
+	return qb
+}
+
 func (qb *QueryBuilder) Limit(n int) *QueryBuilder {
 	qb.limit = n
 	return qb
 }
 
-func (qb *QueryBuilder) Build() string {
+// Offset skips the first n rows of the result.
+func (qb *QueryBuilder) Offset(n int) *QueryBuilder {
+	qb.offset = n
+	return qb
+}
+
+// Build assembles the clauses in SQL order: JOIN, WHERE, GROUP BY, ORDER BY, LIMIT, OFFSET.
+// It returns the query along with the WhereEq values for its ? placeholders, in order.
+func (qb *QueryBuilder) Build() (string, []interface{}) {
 	query := fmt.Sprintf("SELECT * FROM %s", qb.table)
+	for _, join := range qb.joins {
+		query += " " + join
+	}
 	if len(qb.wheres) > 0 {
-		query += " WHERE "
-		for i, where := range qb.wheres {
-			if i > 0 {
-				query += " AND "
-			}
-			query += where
-		}
+		query += " WHERE " + strings.Join(qb.wheres, " AND ")
+	}
+	if len(qb.groupBys) > 0 {
+		query += " GROUP BY " + strings.Join(qb.groupBys, ", ")
+	}
+	if len(qb.orderBys) > 0 {
+		query += " ORDER BY " + strings.Join(qb.orderBys, ", ")
 	}
 	if qb.limit > 0 {
 		query += fmt.Sprintf(" LIMIT %d", qb.limit)
 	}
-	return query
+	if qb.offset > 0 {
+		query += fmt.Sprintf(" OFFSET %d", qb.offset)
+	}
+	return query, qb.args
 }
 
 // sortWithClosures demonstrates custom sorting using closure-based comparators.
@@ -271,6 +472,150 @@ func retryWithBackoff(maxAttempts int) func(func() error) error {
 	}
 }
 
+// RetryWithBackoff calls op until it succeeds, maxAttempts calls have failed, or
+// ctx is done. The wait after each failure starts at baseDelay and doubles up to
+// maxDelay; jitter then picks a random wait between half and all of that delay,
+// so no wait exceeds maxDelay. A maxAttempts below 1 is treated as 1.
+func RetryWithBackoff(ctx context.Context, maxAttempts int, baseDelay, maxDelay time.Duration, op func() error) error {
+	return retryWithBackoffSleep(ctx, maxAttempts, baseDelay, maxDelay, op, sleepContext)
+}
+
+// retryWithBackoffSleep implements RetryWithBackoff, waiting between attempts with sleep.
+func retryWithBackoffSleep(
+	ctx context.Context,
+	maxAttempts int,
+	baseDelay, maxDelay time.Duration,
+	op func() error,
+	sleep func(context.Context, time.Duration) error,
+) error {
+	maxAttempts = max(maxAttempts, 1)
+	backoff := min(baseDelay, maxDelay)
+
+	var lastErr error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return retryCancelled(attempt-1, err, lastErr)
+		}
+
+		if lastErr = op(); lastErr == nil {
+			return nil
+		}
+
+		if attempt < maxAttempts {
+			if err := sleep(ctx, jitter(backoff)); err != nil {
+				return retryCancelled(attempt, err, lastErr)
+			}
+			backoff = min(backoff*2, maxDelay)
+		}
+	}
+
+	return fmt.Errorf("all %d attempts failed: %w", maxAttempts, lastErr)
+}
+
+// retryCancelled reports a retry loop stopped by its context, wrapping both the
+// context error and the last operation error, if any.
+func retryCancelled(attempts int, ctxErr, lastErr error) error {
+	if lastErr == nil {
+		return fmt.Errorf("retry cancelled before first attempt: %w", ctxErr)
+	}
+	return fmt.Errorf("retry cancelled after %d attempts: %w (last error: %w)", attempts, ctxErr, lastErr)
+}
+
+// jitter returns a random duration in [d/2, d].
+func jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	half := d / 2
+	return half + rand.N(d-half+1)
+}
+
+// sleepContext waits for d or until ctx is done, returning ctx.Err in the latter case.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// ErrCircuitOpen is returned by a circuit breaker that is rejecting calls.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitBreaker returns a closure that runs operations until maxFailures of
+// them fail in a row, then trips open and fails fast with ErrCircuitOpen.
+// After resetTimeout it half-opens, letting a single probe through: success
+// closes the breaker again, failure reopens it for another resetTimeout.
+// An operation that panics counts as a failure before the panic propagates.
+func CircuitBreaker(maxFailures int, resetTimeout time.Duration) func(func() error) error {
+	return circuitBreakerWithClock(maxFailures, resetTimeout, time.Now)
+}
+
+// circuitBreakerWithClock implements CircuitBreaker using now as its time source.
+func circuitBreakerWithClock(
+	maxFailures int,
+	resetTimeout time.Duration,
+	now func() time.Time,
+) func(func() error) error {
+	var (
+		mu       sync.Mutex
+		failures int // Consecutive failures while closed
+		open     bool
+		openedAt time.Time
+		probing  bool // A half-open probe is in flight
+	)
+
+	return func(operation func() error) (err error) {
+		mu.Lock()
+		probe := false
+		if open {
+			if probing || now().Sub(openedAt) < resetTimeout {
+				mu.Unlock()
+				return ErrCircuitOpen
+			}
+			probing, probe = true, true // Half-open: only this call may proceed
+		}
+		mu.Unlock()
+
+		// Record the outcome even if operation panics, counting the panic as a
+		// failure, so a panicking probe cannot leave the breaker stuck open
+		panicked := true
+		defer func() {
+			mu.Lock()
+			defer mu.Unlock()
+
+			failed := panicked || err != nil
+			if probe {
+				probing = false
+				if failed {
+					openedAt = now()
+					return
+				}
+				open, failures = false, 0
+				return
+			}
+
+			if !failed {
+				failures = 0
+				return
+			}
+
+			failures++
+			if failures >= maxFailures {
+				open, openedAt = true, now()
+			}
+		}()
+
+		err = operation()
+		panicked = false
+		return err
+	}
+}
+
 // pipeline creates a closure that applies a series of transformations in sequence.
 // Demonstrates functional composition using variadic closure parameters.
 func pipeline(funcs ...func(int) int) func(int) int {
@@ -283,6 +628,26 @@ func pipeline(funcs ...func(int) int) func(int) int {
 	}
 }
 
+// Pipe2 composes f and g into a single function that applies f and then g.
+// Unlike pipeline, each step may change the value's type.
+func Pipe2[A, B, C any](f func(A) B, g func(B) C) func(A) C {
+	return func(a A) C {
+		return g(f(a))
+	}
+}
+
+// Pipeline is the generic form of pipeline: it applies fns to a value in order.
+// With no functions it returns its input unchanged.
+func Pipeline[T any](fns ...func(T) T) func(T) T {
+	return func(x T) T {
+		result := x
+		for _, fn := range fns {
+			result = fn(result)
+		}
+		return result
+	}
+}
+
 // debounce creates a closure that delays function execution until after delay has elapsed
 // since the last invocation. Useful for limiting rapid successive calls.
 func debounce(fn func(), delay time.Duration) func() {
@@ -301,6 +666,72 @@ func debounce(fn func(), delay time.Duration) func() {
 	}
 }
 
+// Debounce is like debounce, but the debounced function takes an argument and fn
+// receives the most recent one once delay has passed without another call.
+// cancel discards any pending call; flush runs it immediately instead of waiting.
+func Debounce[T any](fn func(T), delay time.Duration) (call func(T), cancel func(), flush func()) {
+	var (
+		mu         sync.Mutex
+		timer      *time.Timer
+		pending    T
+		hasPending bool
+		generation int // Bumped on every change so stale timers do nothing
+	)
+
+	// take claims the pending argument, if any; mu must be held
+	take := func() (T, bool) {
+		var zero T
+		arg, ok := pending, hasPending
+		pending, hasPending = zero, false
+		generation++
+		if timer != nil {
+			timer.Stop()
+			timer = nil
+		}
+		return arg, ok
+	}
+
+	call = func(arg T) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		take()
+		pending, hasPending = arg, true
+		scheduled := generation
+		timer = time.AfterFunc(delay, func() {
+			mu.Lock()
+			if generation != scheduled {
+				mu.Unlock()
+				return
+			}
+			arg, ok := take()
+			mu.Unlock()
+
+			if ok {
+				fn(arg)
+			}
+		})
+	}
+
+	cancel = func() {
+		mu.Lock()
+		defer mu.Unlock()
+		take()
+	}
+
+	flush = func() {
+		mu.Lock()
+		arg, ok := take()
+		mu.Unlock()
+
+		if ok {
+			fn(arg)
+		}
+	}
+
+	return call, cancel, flush
+}
+
 // throttle creates a closure that limits function execution to at most once per time period.
 // Useful for rate-limiting expensive operations.
 func throttle(fn func(), limit time.Duration) func() {
@@ -319,6 +750,83 @@ func throttle(fn func(), limit time.Duration) func() {
 	}
 }
 
+// ThrottleTrailing is like throttle, but a call dropped during the limit window
+// is not lost: the last one runs once the window ends. cancel discards a
+// pending trailing call.
+func ThrottleTrailing(fn func(), limit time.Duration) (call func(), cancel func()) {
+	return throttleTrailingWithClock(fn, limit, time.Now, func(d time.Duration, f func()) func() bool {
+		return time.AfterFunc(d, f).Stop
+	})
+}
+
+// throttleTrailingWithClock implements ThrottleTrailing using now as its time
+// source and afterFunc to schedule trailing calls; afterFunc returns a stop function.
+func throttleTrailingWithClock(
+	fn func(),
+	limit time.Duration,
+	now func() time.Time,
+	afterFunc func(time.Duration, func()) func() bool,
+) (call func(), cancel func()) {
+	var (
+		mu         sync.Mutex
+		lastRun    time.Time
+		ran        bool        // Whether fn has run yet; lastRun is meaningless until then
+		stop       func() bool // Stops the scheduled trailing call; nil when none is scheduled
+		generation int         // Bumped on every schedule and cancel so stale timers do nothing
+	)
+
+	// trailing runs the call scheduled at the given generation, unless it
+	// has since been cancelled or replaced; a timer that fires while Stop
+	// races it would otherwise run a newer call early
+	trailing := func(scheduled int) {
+		mu.Lock()
+		if generation != scheduled {
+			mu.Unlock()
+			return
+		}
+		generation++
+		stop = nil
+		lastRun = now()
+		mu.Unlock()
+
+		fn()
+	}
+
+	call = func() {
+		mu.Lock()
+		current := now()
+
+		// Leading edge: the window has passed and nothing is queued
+		if stop == nil && (!ran || current.Sub(lastRun) >= limit) {
+			lastRun, ran = current, true
+			mu.Unlock()
+			fn()
+			return
+		}
+
+		// Trailing edge: run once the current window closes
+		if stop == nil {
+			generation++
+			scheduled := generation
+			stop = afterFunc(lastRun.Add(limit).Sub(current), func() { trailing(scheduled) })
+		}
+		mu.Unlock()
+	}
+
+	cancel = func() {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if stop != nil {
+			stop()
+			stop = nil
+			generation++
+		}
+	}
+
+	return call, cancel
+}
+
 // Demo function for advanced closure patterns
 func advancedClosuresExample() {
 	fmt.Println("\n=== Advanced Closure Examples ===")
@@ -346,14 +854,81 @@ func advancedClosuresExample() {
 	fmt.Println(memoFib(10)) // Cache hit
 	fmt.Println(memoFib(15))
 
+	// Generic memoization over any comparable key
+	vowels := Memoize(func(word string) int {
+		fmt.Printf("Counting vowels in %q\n", word)
+		count := 0
+		for _, r := range word {
+			if strings.ContainsRune("aeiou", r) {
+				count++
+			}
+		}
+		return count
+	})
+	fmt.Println(vowels("closure"), vowels("closure"))
+
+	// Memoization whose entries go stale
+	clockTime := MemoizeTTL(func(string) string {
+		return time.Now().Format("15:04:05.000")
+	}, 50*time.Millisecond)
+	first := clockTime("now")
+	time.Sleep(60 * time.Millisecond)
+	fmt.Printf("Cached %s, after expiry %s\n", first, clockTime("now"))
+
+	// Concurrent callers share one computation per key
+	slowSquare := MemoizeSync(func(n int) int {
+		fmt.Printf("Computing square of %d once\n", n)
+		time.Sleep(10 * time.Millisecond)
+		return n * n
+	})
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			slowSquare(12)
+		}()
+	}
+	wg.Wait()
+	fmt.Println(slowSquare(12))
+
+	// Cache-aside with a loader that can fail
+	prices := make(map[string]float64)
+	loadPrice := func(sku string) (float64, error) {
+		fmt.Printf("Loading price for %s\n", sku)
+		if sku == "" {
+			return 0, fmt.Errorf("empty sku")
+		}
+		return 9.99, nil
+	}
+	for _, sku := range []string{"A-1", "A-1", ""} {
+		if price, err := CacheAside(prices, sku, loadPrice); err != nil {
+			fmt.Printf("Cache-aside error: %v\n", err)
+		} else {
+			fmt.Printf("Price of %s: %.2f\n", sku, price)
+		}
+	}
+
 	// 4. Event Emitter
 	fmt.Println("\n--- Event Emitter ---")
-	on, emit := createEventEmitter()
+	on, emit, off := createEventEmitter()
 	on("login", func() { fmt.Println("User logged in!") })
-	on("login", func() { fmt.Println("Send welcome email") })
+	welcome := on("login", func() { fmt.Println("Send welcome email") })
 	on("logout", func() { fmt.Println("User logged out!") })
 	emit("login")
 	emit("logout")
+	off("login", welcome) // Returning users get no welcome email
+	emit("login")
+
+	type loginEvent struct {
+		User string
+		At   time.Time
+	}
+	onLogin, emitLogin := CreateTypedEmitter[loginEvent]()
+	onLogin("login", func(e loginEvent) {
+		fmt.Printf("%s logged in at %s\n", e.User, e.At.Format("15:04"))
+	})
+	emitLogin("login", loginEvent{User: "alice", At: time.Now()})
 
 	// 5. Generators
 	fmt.Println("\n--- Generators ---")
@@ -374,13 +949,23 @@ func advancedClosuresExample() {
 	// 6. Query Builder
 	fmt.Println("\n--- Query Builder ---")
 	newQuery := createQueryBuilder()
-	query := newQuery("users").
+	query, _ := newQuery("users").
 		Where("age > 18").
 		Where("city = 'NYC'").
 		Limit(10).
 		Build()
 	fmt.Println("Query:", query)
 
+	report, args := newQuery("orders o").
+		Join("users u", "u.id = o.user_id").
+		WhereEq("o.status", "paid").
+		GroupBy("u.city").
+		OrderBy("u.city", false).
+		Limit(20).
+		Offset(40).
+		Build()
+	fmt.Println("Report query:", report, args)
+
 	// 7. Sorting
 	sortWithClosures()
 
@@ -399,6 +984,23 @@ func advancedClosuresExample() {
 		fmt.Printf("Final error: %v\n", err)
 	}
 
+	// Bounded, jittered retries that stop when the context expires
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	err = RetryWithBackoff(ctx, 10, 20*time.Millisecond, 80*time.Millisecond, func() error {
+		return fmt.Errorf("service unavailable")
+	})
+	fmt.Printf("RetryWithBackoff: %v\n", err)
+
+	// Circuit breaker that fails fast once a dependency keeps failing
+	breaker := CircuitBreaker(2, 100*time.Millisecond)
+	for i := 1; i <= 4; i++ {
+		err := breaker(func() error { return fmt.Errorf("connection refused") })
+		fmt.Printf("Breaker call %d: %v\n", i, err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	fmt.Printf("Breaker probe after timeout: %v\n", breaker(func() error { return nil }))
+
 	// 9. Pipeline
 	fmt.Println("\n--- Pipeline ---")
 	double := func(x int) int { return x * 2 }
@@ -409,6 +1011,10 @@ func advancedClosuresExample() {
 	result := transform(5) // (5*2 + 10)^2 = 400
 	fmt.Printf("Pipeline(5) = %d\n", result)
 
+	shout := Pipeline(strings.TrimSpace, strings.ToUpper)
+	digitCount := Pipe2(strconv.Itoa, func(s string) int { return len(s) })
+	fmt.Printf("Pipeline(\"  hi  \") = %q, digits in 400 = %d\n", shout("  hi  "), digitCount(result))
+
 	// 10. Debounce/Throttle
 	fmt.Println("\n--- Debounce/Throttle ---")
 
@@ -426,9 +1032,24 @@ func advancedClosuresExample() {
 	}
 	time.Sleep(600 * time.Millisecond) // Wait for debounce to execute
 
+	search, _, flushSearch := Debounce(func(query string) {
+		fmt.Printf("Searching for %q\n", query)
+	}, 500*time.Millisecond)
+	for _, query := range []string{"g", "go", "gop"} {
+		search(query)
+	}
+	flushSearch() // Run the pending search now instead of waiting
+
 	fmt.Println("\nThrottled calls (rate limited):")
 	for i := 0; i < 5; i++ {
 		throttledSave()
 		time.Sleep(300 * time.Millisecond)
 	}
+
+	fmt.Println("\nThrottled calls with trailing edge (burst end is kept):")
+	trailingSave, _ := ThrottleTrailing(saveAction, 500*time.Millisecond)
+	for i := 0; i < 3; i++ {
+		trailingSave()
+	}
+	time.Sleep(600 * time.Millisecond) // Wait for the trailing call
 }
@@ -0,0 +1,761 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCacheAside(t *testing.T) {
+	cache := make(map[string]int)
+	errNotFound := errors.New("not found")
+	loads := 0
+	load := func(key string) (int, error) {
+		loads++
+		if key == "missing" {
+			return 0, errNotFound
+		}
+		return len(key), nil
+	}
+
+	for i := 0; i < 3; i++ {
+		val, err := CacheAside(cache, "hello", load)
+		if err != nil || val != 5 {
+			t.Errorf("CacheAside(%q) = (%d, %v); want (5, nil)", "hello", val, err)
+		}
+	}
+	if loads != 1 {
+		t.Errorf("loader ran %d times for a cached key; want 1", loads)
+	}
+
+	if _, err := CacheAside(cache, "missing", load); !errors.Is(err, errNotFound) {
+		t.Errorf("CacheAside(%q) error = %v; want %v", "missing", err, errNotFound)
+	}
+	if _, cached := cache["missing"]; cached {
+		t.Error("failed load should not be cached")
+	}
+}
+
+func TestMemoize(t *testing.T) {
+	calls := make(map[string]int)
+	length := Memoize(func(s string) int {
+		calls[s]++
+		return len(s)
+	})
+
+	inputs := []string{"go", "gopher", "go", "", "gopher", "go", ""}
+	for _, input := range inputs {
+		if got := length(input); got != len(input) {
+			t.Errorf("memoized length(%q) = %d; want %d", input, got, len(input))
+		}
+	}
+
+	for key, count := range calls {
+		if count != 1 {
+			t.Errorf("fn ran %d times for %q; want 1", count, key)
+		}
+	}
+	if len(calls) != 3 {
+		t.Errorf("fn ran for %d distinct keys; want 3", len(calls))
+	}
+}
+
+func TestMemoizeTTL(t *testing.T) {
+	current := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	now := func() time.Time { return current }
+
+	calls := 0
+	square := memoizeTTLWithClock(func(n int) int {
+		calls++
+		return n * n
+	}, time.Minute, now)
+
+	tests := []struct {
+		advance time.Duration
+		key     int
+		calls   int // Total calls expected after this step
+	}{
+		{0, 3, 1},                // First call computes
+		{30 * time.Second, 3, 1}, // Within the window: cache hit
+		{0, 4, 2},                // Different key computes independently
+		{29 * time.Second, 3, 2}, // Still 59s after computing 3
+		{time.Second, 3, 3},      // Exactly ttl later: expired, recompute
+		{30 * time.Second, 3, 3}, // Fresh entry from the recompute
+		{31 * time.Second, 4, 4}, // Key 4 expired as well
+	}
+
+	for i, test := range tests {
+		current = current.Add(test.advance)
+		if got := square(test.key); got != test.key*test.key {
+			t.Errorf("step %d: square(%d) = %d; want %d", i, test.key, got, test.key*test.key)
+		}
+		if calls != test.calls {
+			t.Errorf("step %d: fn ran %d times; want %d", i, calls, test.calls)
+		}
+	}
+}
+
+func TestMemoizeSyncSingleFlight(t *testing.T) {
+	const goroutines = 64
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	square := MemoizeSync(func(n int) int {
+		calls.Add(1)
+		<-release // Hold the computation open so callers pile up
+		return n * n
+	})
+
+	var wg sync.WaitGroup
+	results := make([]int, goroutines)
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = square(9)
+		}(i)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("fn ran %d times for one key; want 1", got)
+	}
+	for i, got := range results {
+		if got != 81 {
+			t.Errorf("caller %d got %d; want 81", i, got)
+		}
+	}
+
+	// Distinct keys still compute independently
+	if got := square(3); got != 9 || calls.Load() != 2 {
+		t.Errorf("square(3) = %d after %d calls; want 9 after 2", got, calls.Load())
+	}
+}
+
+func TestMemoizeSyncPanicNotCached(t *testing.T) {
+	calls := 0
+	fail := true
+	parse := MemoizeSync(func(s string) int {
+		calls++
+		if fail {
+			panic("transient failure")
+		}
+		return len(s)
+	})
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected panic to propagate to the caller")
+			}
+		}()
+		parse("key")
+	}()
+
+	fail = false
+	if got := parse("key"); got != 3 || calls != 2 {
+		t.Errorf("parse after panic = %d after %d calls; want 3 after 2", got, calls)
+	}
+}
+
+func TestRetryWithBackoffDelays(t *testing.T) {
+	const (
+		baseDelay = 10 * time.Millisecond
+		maxDelay  = 50 * time.Millisecond
+	)
+
+	var delays []time.Duration
+	sleep := func(_ context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	}
+
+	errFailed := errors.New("failed")
+	calls := 0
+	err := retryWithBackoffSleep(context.Background(), 8, baseDelay, maxDelay, func() error {
+		calls++
+		return errFailed
+	}, sleep)
+
+	if !errors.Is(err, errFailed) {
+		t.Errorf("RetryWithBackoff error = %v; want wrapping %v", err, errFailed)
+	}
+	if calls != 8 {
+		t.Errorf("op ran %d times; want 8", calls)
+	}
+	if len(delays) != 7 {
+		t.Fatalf("slept %d times; want 7", len(delays))
+	}
+
+	// Nominal delays are 10, 20, 40, 50, 50, ... ms; jitter keeps each in [nominal/2, nominal]
+	nominal := baseDelay
+	for i, d := range delays {
+		if d > maxDelay {
+			t.Errorf("delay %d = %v; exceeds maxDelay %v", i, d, maxDelay)
+		}
+		if d < nominal/2 || d > nominal {
+			t.Errorf("delay %d = %v; want within [%v, %v]", i, d, nominal/2, nominal)
+		}
+		nominal = min(nominal*2, maxDelay)
+	}
+}
+
+func TestRetryWithBackoffSucceeds(t *testing.T) {
+	calls := 0
+	err := RetryWithBackoff(context.Background(), 5, time.Millisecond, 2*time.Millisecond, func() error {
+		calls++
+		if calls < 3 {
+			return errors.New("transient")
+		}
+		return nil
+	})
+
+	if err != nil || calls != 3 {
+		t.Errorf("RetryWithBackoff = %v after %d calls; want nil after 3", err, calls)
+	}
+}
+
+func TestRetryWithBackoffCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errFailed := errors.New("failed")
+	calls := 0
+	start := time.Now()
+	err := RetryWithBackoff(ctx, 100, time.Hour, time.Hour, func() error {
+		calls++
+		cancel()
+		return errFailed
+	})
+
+	// Cancelling interrupts the hour-long backoff and stops further attempts
+	if calls != 1 {
+		t.Errorf("op ran %d times after cancellation; want 1", calls)
+	}
+	if !errors.Is(err, context.Canceled) || !errors.Is(err, errFailed) {
+		t.Errorf("RetryWithBackoff error = %v; want wrapping %v and %v", err, context.Canceled, errFailed)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("RetryWithBackoff took %v after cancellation; want prompt return", elapsed)
+	}
+
+	// An already-cancelled context never calls op
+	calls = 0
+	err = RetryWithBackoff(ctx, 3, time.Millisecond, time.Millisecond, func() error {
+		calls++
+		return nil
+	})
+	if calls != 0 || !errors.Is(err, context.Canceled) {
+		t.Errorf("RetryWithBackoff on cancelled ctx = %v after %d calls; want %v after 0", err, calls, context.Canceled)
+	}
+}
+
+func TestPipe2(t *testing.T) {
+	toString := func(n int) string { return strconv.Itoa(n) }
+	length := func(s string) int { return len(s) }
+	digits := Pipe2(toString, length)
+
+	tests := []struct {
+		input    int
+		expected int
+	}{
+		{0, 1},
+		{42, 2},
+		{-100, 4},
+		{123456789, 9},
+	}
+
+	for _, test := range tests {
+		if got := digits(test.input); got != test.expected {
+			t.Errorf("Pipe2(Itoa, len)(%d) = %d; want %d", test.input, got, test.expected)
+		}
+	}
+}
+
+func TestPipeline(t *testing.T) {
+	double := func(x int) int { return x * 2 }
+	addTen := func(x int) int { return x + 10 }
+	square := func(x int) int { return x * x }
+
+	tests := []struct {
+		name     string
+		fns      []func(int) int
+		input    int
+		expected int
+	}{
+		{"ordered", []func(int) int{double, addTen, square}, 5, 400},
+		{"reordered", []func(int) int{square, addTen, double}, 5, 70},
+		{"single", []func(int) int{addTen}, 1, 11},
+		{"empty", nil, 7, 7},
+	}
+
+	for _, test := range tests {
+		if got := Pipeline(test.fns...)(test.input); got != test.expected {
+			t.Errorf("%s: Pipeline(%d) = %d; want %d", test.name, test.input, got, test.expected)
+		}
+	}
+}
+
+func TestDebounceLastArgument(t *testing.T) {
+	var mu sync.Mutex
+	var got []int
+	call, _, _ := Debounce(func(n int) {
+		mu.Lock()
+		defer mu.Unlock()
+		got = append(got, n)
+	}, 30*time.Millisecond)
+
+	for i := 1; i <= 5; i++ {
+		call(i)
+		time.Sleep(5 * time.Millisecond)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(got) != 1 || got[0] != 5 {
+		t.Errorf("debounced calls = %v; want [5]", got)
+	}
+}
+
+func TestDebounceCancelAndFlush(t *testing.T) {
+	var mu sync.Mutex
+	var got []string
+	call, cancel, flush := Debounce(func(s string) {
+		mu.Lock()
+		defer mu.Unlock()
+		got = append(got, s)
+	}, 30*time.Millisecond)
+
+	call("cancelled")
+	cancel()
+
+	call("first")
+	call("flushed")
+	flush() // Runs synchronously with the latest argument
+	flush() // Nothing pending: no-op
+
+	time.Sleep(60 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if !reflect.DeepEqual(got, []string{"flushed"}) {
+		t.Errorf("debounced calls = %v; want [flushed]", got)
+	}
+}
+
+// fakeScheduler is a manual clock whose scheduled callbacks run on advance.
+type fakeScheduler struct {
+	current time.Time
+	tasks   []*fakeTask
+}
+
+type fakeTask struct {
+	at      time.Time
+	fn      func()
+	stopped bool
+}
+
+func (s *fakeScheduler) now() time.Time {
+	return s.current
+}
+
+func (s *fakeScheduler) afterFunc(d time.Duration, fn func()) func() bool {
+	task := &fakeTask{at: s.current.Add(d), fn: fn}
+	s.tasks = append(s.tasks, task)
+	return func() bool {
+		wasPending := !task.stopped
+		task.stopped = true
+		return wasPending
+	}
+}
+
+// advance moves the clock forward by d, running callbacks that come due.
+func (s *fakeScheduler) advance(d time.Duration) {
+	s.current = s.current.Add(d)
+	for _, task := range s.tasks {
+		if !task.stopped && !task.at.After(s.current) {
+			task.stopped = true
+			task.fn()
+		}
+	}
+}
+
+func TestThrottleTrailing(t *testing.T) {
+	clock := &fakeScheduler{current: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	calls := 0
+	call, _ := throttleTrailingWithClock(func() { calls++ }, time.Second, clock.now, clock.afterFunc)
+
+	call() // Leading edge runs immediately
+	if calls != 1 {
+		t.Fatalf("after leading call: ran %d times; want 1", calls)
+	}
+
+	// A burst within the window collapses into one trailing call
+	clock.advance(200 * time.Millisecond)
+	call()
+	clock.advance(200 * time.Millisecond)
+	call()
+	if calls != 1 {
+		t.Errorf("during window: ran %d times; want 1", calls)
+	}
+
+	clock.advance(599 * time.Millisecond)
+	if calls != 1 {
+		t.Errorf("just before window end: ran %d times; want 1", calls)
+	}
+	clock.advance(time.Millisecond)
+	if calls != 2 {
+		t.Errorf("at window end: ran %d times; want 2 (trailing call)", calls)
+	}
+
+	// The trailing call opened a new window, so an immediate call is deferred
+	call()
+	if calls != 2 {
+		t.Errorf("right after trailing call: ran %d times; want 2", calls)
+	}
+	clock.advance(time.Second)
+	if calls != 3 {
+		t.Errorf("after second window: ran %d times; want 3", calls)
+	}
+
+	// Once idle for a full window, the next call is a leading call again
+	clock.advance(2 * time.Second)
+	call()
+	if calls != 4 {
+		t.Errorf("after idle period: ran %d times; want 4", calls)
+	}
+}
+
+func TestThrottleTrailingCancel(t *testing.T) {
+	clock := &fakeScheduler{current: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	calls := 0
+	call, cancel := throttleTrailingWithClock(func() { calls++ }, time.Second, clock.now, clock.afterFunc)
+
+	call()
+	call() // Queued as trailing
+	cancel()
+	clock.advance(2 * time.Second)
+
+	if calls != 1 {
+		t.Errorf("ran %d times after cancel; want 1", calls)
+	}
+
+	call() // Cancellation does not disable the throttle
+	if calls != 2 {
+		t.Errorf("ran %d times after cancel and new call; want 2", calls)
+	}
+}
+
+func TestThrottleTrailingCancelThenCall(t *testing.T) {
+	clock := &fakeScheduler{current: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	calls := 0
+	call, cancel := throttleTrailingWithClock(func() { calls++ }, time.Second, clock.now, clock.afterFunc)
+
+	call()
+	call() // Queued as trailing
+	stale := clock.tasks[len(clock.tasks)-1].fn
+
+	cancel()
+	call() // Queued again with a new timer
+
+	// The cancelled timer fires anyway, as if it raced with stop
+	stale()
+	if calls != 1 {
+		t.Errorf("ran %d times after stale timer fired; want 1", calls)
+	}
+
+	clock.advance(time.Second)
+	if calls != 2 {
+		t.Errorf("ran %d times after window closed; want 2", calls)
+	}
+}
+
+func TestEventEmitterOff(t *testing.T) {
+	on, emit, off := createEventEmitter()
+
+	var fired []string
+	first := on("save", func() { fired = append(fired, "first") })
+	on("save", func() { fired = append(fired, "second") })
+	other := on("load", func() { fired = append(fired, "load") })
+
+	emit("save")
+	if !reflect.DeepEqual(fired, []string{"first", "second"}) {
+		t.Errorf("before off: fired = %v; want [first second]", fired)
+	}
+
+	fired = nil
+	off("save", first)
+	off("save", other) // id belongs to another event: no-op
+	off("save", 999)   // Unknown id: no-op
+	emit("save")
+	emit("load")
+	if !reflect.DeepEqual(fired, []string{"second", "load"}) {
+		t.Errorf("after off: fired = %v; want [second load]", fired)
+	}
+}
+
+func TestEventEmitterOffDuringEmit(t *testing.T) {
+	on, emit, off := createEventEmitter()
+
+	calls := 0
+	var onceID int
+	onceID = on("tick", func() {
+		calls++
+		off("tick", onceID) // A handler may remove itself
+	})
+	on("tick", func() { calls++ })
+
+	emit("tick")
+	emit("tick")
+	if calls != 3 {
+		t.Errorf("handler calls = %d; want 3", calls)
+	}
+}
+
+func TestCreateTypedEmitter(t *testing.T) {
+	type order struct {
+		ID    int
+		Total float64
+	}
+
+	on, emit := CreateTypedEmitter[order]()
+
+	var received []order
+	var totals float64
+	on("placed", func(o order) { received = append(received, o) })
+	on("placed", func(o order) { totals += o.Total })
+	on("cancelled", func(order) { t.Error("cancelled handler should not fire") })
+
+	emit("placed", order{ID: 1, Total: 9.5})
+	emit("placed", order{ID: 2, Total: 0.5})
+	emit("unknown", order{ID: 3}) // No handlers: no-op
+
+	want := []order{{ID: 1, Total: 9.5}, {ID: 2, Total: 0.5}}
+	if !reflect.DeepEqual(received, want) {
+		t.Errorf("received = %v; want %v", received, want)
+	}
+	if totals != 10 {
+		t.Errorf("totals = %g; want 10", totals)
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	current := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := func() time.Time { return current }
+	breaker := circuitBreakerWithClock(3, 10*time.Second, now)
+
+	errDown := errors.New("service down")
+	calls := 0
+	failing := func() error {
+		calls++
+		return errDown
+	}
+	healthy := func() error {
+		calls++
+		return nil
+	}
+
+	// A success resets the consecutive failure count
+	breaker(failing)
+	breaker(failing)
+	breaker(healthy)
+	breaker(failing)
+	breaker(failing)
+	if err := breaker(healthy); err != nil {
+		t.Fatalf("breaker tripped after non-consecutive failures: %v", err)
+	}
+
+	// Three consecutive failures trip the breaker
+	for i := 0; i < 3; i++ {
+		if err := breaker(failing); !errors.Is(err, errDown) {
+			t.Errorf("failure %d: error = %v; want %v", i+1, err, errDown)
+		}
+	}
+
+	// While open, calls fail fast without running the operation
+	calls = 0
+	current = current.Add(9 * time.Second)
+	if err := breaker(healthy); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("open breaker error = %v; want %v", err, ErrCircuitOpen)
+	}
+	if calls != 0 {
+		t.Errorf("open breaker ran operation %d times; want 0", calls)
+	}
+
+	// After the timeout a failed probe reopens the breaker for another timeout
+	current = current.Add(time.Second)
+	if err := breaker(failing); !errors.Is(err, errDown) {
+		t.Errorf("failed probe error = %v; want %v", err, errDown)
+	}
+	current = current.Add(5 * time.Second)
+	if err := breaker(healthy); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("after failed probe error = %v; want %v", err, ErrCircuitOpen)
+	}
+
+	// A successful probe closes the breaker
+	current = current.Add(5 * time.Second)
+	if err := breaker(healthy); err != nil {
+		t.Errorf("successful probe error = %v; want nil", err)
+	}
+	if err := breaker(failing); !errors.Is(err, errDown) {
+		t.Errorf("after recovery error = %v; want %v", err, errDown)
+	}
+	if err := breaker(healthy); err != nil {
+		t.Errorf("recovered breaker error = %v; want nil", err)
+	}
+}
+
+func TestCircuitBreakerSingleProbe(t *testing.T) {
+	current := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var mu sync.Mutex
+	now := func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return current
+	}
+	breaker := circuitBreakerWithClock(1, time.Second, now)
+
+	breaker(func() error { return errors.New("fail") })
+	mu.Lock()
+	current = current.Add(time.Second)
+	mu.Unlock()
+
+	// While the probe is in flight, other callers still fail fast
+	started := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- breaker(func() error {
+			close(started)
+			<-release
+			return nil
+		})
+	}()
+	<-started
+
+	if err := breaker(func() error { return nil }); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("concurrent call during probe error = %v; want %v", err, ErrCircuitOpen)
+	}
+
+	close(release)
+	if err := <-done; err != nil {
+		t.Errorf("probe error = %v; want nil", err)
+	}
+}
+
+func TestCircuitBreakerPanickingProbe(t *testing.T) {
+	current := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := func() time.Time { return current }
+	breaker := circuitBreakerWithClock(1, time.Second, now)
+
+	breaker(func() error { return errors.New("fail") })
+	current = current.Add(time.Second)
+
+	func() {
+		defer func() {
+			if r := recover(); r != "boom" {
+				t.Errorf("recovered %v; want the probe's panic to propagate", r)
+			}
+		}()
+		breaker(func() error { panic("boom") })
+	}()
+
+	// The panic counts as a failed probe, reopening the breaker
+	if err := breaker(func() error { return nil }); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("call right after panicking probe error = %v; want %v", err, ErrCircuitOpen)
+	}
+
+	current = current.Add(time.Second)
+	if err := breaker(func() error { return nil }); err != nil {
+		t.Errorf("probe after resetTimeout error = %v; want nil", err)
+	}
+	if err := breaker(func() error { return nil }); err != nil {
+		t.Errorf("call after successful probe error = %v; want nil", err)
+	}
+}
+
+func TestQueryBuilder(t *testing.T) {
+	newQuery := createQueryBuilder()
+
+	tests := []struct {
+		name     string
+		query    *QueryBuilder
+		expected string
+	}{
+		{
+			"bare table",
+			newQuery("users"),
+			"SELECT * FROM users",
+		},
+		{
+			"where and limit",
+			newQuery("users").Where("age > 18").Where("city = 'NYC'").Limit(10),
+			"SELECT * FROM users WHERE age > 18 AND city = 'NYC' LIMIT 10",
+		},
+		{
+			"all clauses in SQL order regardless of call order",
+			newQuery("orders o").
+				Offset(40).
+				OrderBy("total", true).
+				Limit(20).
+				GroupBy("u.city", "u.country").
+				Where("o.status = 'paid'").
+				Join("users u", "u.id = o.user_id").
+				Join("stores s", "s.id = o.store_id").
+				OrderBy("u.city", false),
+			"SELECT * FROM orders o JOIN users u ON u.id = o.user_id JOIN stores s ON s.id = o.store_id" +
+				" WHERE o.status = 'paid' GROUP BY u.city, u.country ORDER BY total DESC, u.city" +
+				" LIMIT 20 OFFSET 40",
+		},
+		{
+			"offset without limit",
+			newQuery("logs").Offset(5),
+			"SELECT * FROM logs OFFSET 5",
+		},
+	}
+
+	for _, test := range tests {
+		got, args := test.query.Build()
+		if got != test.expected {
+			t.Errorf("%s:\n got  %q\n want %q", test.name, got, test.expected)
+		}
+		if len(args) != 0 {
+			t.Errorf("%s: args = %v; want none", test.name, args)
+		}
+	}
+}
+
+func TestQueryBuilderWhereEq(t *testing.T) {
+	query, args := createQueryBuilder()("users").
+		WhereEq("name", "Robert'); DROP TABLE users;--").
+		Where("active = 1").
+		WhereEq("age", 30).
+		WhereEq("city", "NYC").
+		Limit(5).
+		Build()
+
+	wantQuery := "SELECT * FROM users WHERE name = ? AND active = 1 AND age = ? AND city = ? LIMIT 5"
+	if query != wantQuery {
+		t.Errorf("query:\n got  %q\n want %q", query, wantQuery)
+	}
+
+	wantArgs := []interface{}{"Robert'); DROP TABLE users;--", 30, "NYC"}
+	if !reflect.DeepEqual(args, wantArgs) {
+		t.Errorf("args = %v; want %v", args, wantArgs)
+	}
+
+	// One argument per placeholder, in the same order
+	if placeholders := strings.Count(query, "?"); placeholders != len(args) {
+		t.Errorf("query has %d placeholders but %d args", placeholders, len(args))
+	}
+}
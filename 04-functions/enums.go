@@ -15,25 +15,25 @@ const (
 	Failed                  // 3
 )
 
+// statusSet holds the valid Status values and their names
+var statusSet = NewEnumSet(
+	[]Status{Pending, Running, Completed, Failed},
+	[]string{"Pending", "Running", "Completed", "Failed"},
+)
+
 // String representation
 func (s Status) String() string {
-	switch s {
-	case Pending:
-		return "Pending"
-	case Running:
-		return "Running"
-	case Completed:
-		return "Completed"
-	case Failed:
-		return "Failed"
-	default:
-		return "Unknown"
-	}
+	return statusSet.String(s)
 }
 
 // Validation
 func (s Status) IsValid() bool {
-	return s >= Pending && s <= Failed
+	return statusSet.IsValid(s)
+}
+
+// ParseStatus converts a name such as "Running" into a Status
+func ParseStatus(name string) (Status, error) {
+	return statusSet.Parse(name)
 }
 
 // Terminal states
@@ -41,6 +41,63 @@ func (s Status) IsTerminal() bool {
 	return s == Completed || s == Failed
 }
 
+// EnumSet removes the String/IsValid/Parse boilerplate from iota enums.
+// It maps each valid value to its name and back.
+type EnumSet[T ~int] struct {
+	values []T
+	names  map[T]string
+	byName map[string]T
+}
+
+// NewEnumSet builds an EnumSet from parallel slices of values and names.
+// It panics if the slices differ in length, since that is a programming error.
+func NewEnumSet[T ~int](values []T, names []string) *EnumSet[T] {
+	if len(values) != len(names) {
+		panic(fmt.Sprintf("NewEnumSet: %d values but %d names", len(values), len(names)))
+	}
+
+	set := &EnumSet[T]{
+		values: values,
+		names:  make(map[T]string, len(values)),
+		byName: make(map[string]T, len(values)),
+	}
+	for i, v := range values {
+		set.names[v] = names[i]
+		set.byName[names[i]] = v
+	}
+	return set
+}
+
+// IsValid reports whether v is one of the enum's values
+func (e *EnumSet[T]) IsValid(v T) bool {
+	_, ok := e.names[v]
+	return ok
+}
+
+// String returns the name of v, or "Unknown" for invalid values
+func (e *EnumSet[T]) String(v T) string {
+	if name, ok := e.names[v]; ok {
+		return name
+	}
+	return "Unknown"
+}
+
+// Parse returns the value with the given name
+func (e *EnumSet[T]) Parse(name string) (T, error) {
+	if v, ok := e.byName[name]; ok {
+		return v, nil
+	}
+	var zero T
+	return zero, fmt.Errorf("unknown %T name %q", zero, name)
+}
+
+// Values returns the valid values in declaration order
+func (e *EnumSet[T]) Values() []T {
+	values := make([]T, len(e.values))
+	copy(values, e.values)
+	return values
+}
+
 // Understanding iota
 func demonstrateIota() {
 	fmt.Println("=== Understanding iota ===")
@@ -231,11 +288,17 @@ func enumsExample() {
 	fmt.Printf("Is terminal: %t\n", status.IsTerminal())
 
 	// Test all statuses
-	statuses := []Status{Pending, Running, Completed, Failed}
-	for _, s := range statuses {
+	for _, s := range statusSet.Values() {
 		fmt.Printf("%s: valid=%t, terminal=%t\n", s, s.IsValid(), s.IsTerminal())
 	}
 
+	if parsed, err := ParseStatus("Completed"); err == nil {
+		fmt.Printf("Parsed status: %s (%d)\n", parsed, int(parsed))
+	}
+	if _, err := ParseStatus("Paused"); err != nil {
+		fmt.Printf("Parse error: %v\n", err)
+	}
+
 	demonstrateIota()
 
 	fmt.Println("\n=== Custom Values ===")
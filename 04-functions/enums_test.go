@@ -0,0 +1,42 @@
+package main
+
+import "testing"
+
+func TestStatusEnumSet(t *testing.T) {
+	tests := []struct {
+		status   Status
+		name     string
+		expected bool
+	}{
+		{Pending, "Pending", true},
+		{Failed, "Failed", true},
+		{Status(99), "Unknown", false},
+	}
+
+	for _, test := range tests {
+		if result := test.status.String(); result != test.name {
+			t.Errorf("Status(%d).String() = %q; want %q", int(test.status), result, test.name)
+		}
+		if result := test.status.IsValid(); result != test.expected {
+			t.Errorf("Status(%d).IsValid() = %t; want %t", int(test.status), result, test.expected)
+		}
+	}
+
+	status, err := ParseStatus("Running")
+	if err != nil || status != Running {
+		t.Errorf("ParseStatus(%q) = (%v, %v); want (Running, nil)", "Running", status, err)
+	}
+
+	if _, err := ParseStatus("running"); err == nil {
+		t.Errorf("ParseStatus(%q) expected error but got none", "running")
+	}
+}
+
+func TestNewEnumSetPanicsOnMismatch(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("NewEnumSet with mismatched slices should panic")
+		}
+	}()
+	NewEnumSet([]Direction{North, East}, []string{"North"})
+}
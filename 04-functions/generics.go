@@ -1,8 +1,11 @@
 package main
 
 import (
+	"container/list"
 	"fmt"
+	"sort"
 	"strconv"
+	"sync"
 )
 
 // Single type parameter with constraint
@@ -49,6 +52,154 @@ func mapSlice[T any, U any](slice []T, mapper func(T) U) []U {
 	return result
 }
 
+// Count returns the number of elements in slice that satisfy pred.
+func Count[T any](slice []T, pred func(T) bool) int {
+	count := 0
+	for _, item := range slice {
+		if pred(item) {
+			count++
+		}
+	}
+	return count
+}
+
+// Find returns the first element in slice that satisfies pred, or false if none does.
+func Find[T any](slice []T, pred func(T) bool) (T, bool) {
+	for _, item := range slice {
+		if pred(item) {
+			return item, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// All reports whether every element in slice satisfies pred.
+// It returns true for an empty slice.
+func All[T any](slice []T, pred func(T) bool) bool {
+	for _, item := range slice {
+		if !pred(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// Any reports whether at least one element in slice satisfies pred.
+// It returns false for an empty slice.
+func Any[T any](slice []T, pred func(T) bool) bool {
+	for _, item := range slice {
+		if pred(item) {
+			return true
+		}
+	}
+	return false
+}
+
+// Partition splits slice in a single pass into the elements that satisfy pred
+// and those that do not, preserving input order in both.
+func Partition[T any](slice []T, pred func(T) bool) (matched, rest []T) {
+	matched = make([]T, 0, len(slice))
+	rest = make([]T, 0, len(slice))
+	for _, item := range slice {
+		if pred(item) {
+			matched = append(matched, item)
+		} else {
+			rest = append(rest, item)
+		}
+	}
+	return matched, rest
+}
+
+// Reduce folds slice into a single value, applying fn to the accumulator and
+// each element from left to right. It returns initial for an empty slice.
+func Reduce[T, U any](slice []T, initial U, fn func(U, T) U) U {
+	acc := initial
+	for _, v := range slice {
+		acc = fn(acc, v)
+	}
+	return acc
+}
+
+// GroupBy partitions slice by the key keyFn derives from each element.
+// Elements keep their input order within each group.
+func GroupBy[T any, K comparable](slice []T, keyFn func(T) K) map[K][]T {
+	groups := make(map[K][]T)
+	for _, v := range slice {
+		key := keyFn(v)
+		groups[key] = append(groups[key], v)
+	}
+	return groups
+}
+
+// Keys returns the keys of m in unspecified order.
+// The result is empty but non-nil for an empty map.
+func Keys[K comparable, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// Values returns the values of m in unspecified order.
+// The result is empty but non-nil for an empty map.
+func Values[K comparable, V any](m map[K]V) []V {
+	values := make([]V, 0, len(m))
+	for _, v := range m {
+		values = append(values, v)
+	}
+	return values
+}
+
+// Distinct returns the elements of slice with duplicates removed, keeping the
+// first occurrence of each. The input slice is not modified.
+func Distinct[T comparable](slice []T) []T {
+	seen := make(map[T]struct{}, len(slice))
+	result := make([]T, 0, len(slice))
+	for _, item := range slice {
+		if _, ok := seen[item]; ok {
+			continue
+		}
+		seen[item] = struct{}{}
+		result = append(result, item)
+	}
+	return result
+}
+
+// Pair holds two values of possibly different types.
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Zip pairs up the elements of a and b by index.
+// The result stops at the shorter of the two slices.
+func Zip[A, B any](a []A, b []B) []Pair[A, B] {
+	n := min(len(a), len(b))
+	result := make([]Pair[A, B], n)
+	for i := 0; i < n; i++ {
+		result[i] = Pair[A, B]{First: a[i], Second: b[i]}
+	}
+	return result
+}
+
+// Chunk splits s into consecutive batches of size elements; the final batch
+// holds the remainder and may be shorter. It returns nil if size <= 0.
+// The batches share s's backing array.
+func Chunk[T any](s []T, size int) [][]T {
+	if size <= 0 {
+		return nil
+	}
+
+	chunks := make([][]T, 0, (len(s)+size-1)/size)
+	for start := 0; start < len(s); start += size {
+		end := min(start+size, len(s))
+		chunks = append(chunks, s[start:end:end])
+	}
+	return chunks
+}
+
 // Stack Generic stack implementation
 type Stack[T any] struct {
 	items []T
@@ -78,6 +229,250 @@ func (s *Stack[T]) Size() int {
 	return len(s.items)
 }
 
+// Peek returns the top item without removing it, or false if the stack is empty.
+func (s *Stack[T]) Peek() (T, bool) {
+	var zero T
+	if len(s.items) == 0 {
+		return zero, false
+	}
+	return s.items[len(s.items)-1], true
+}
+
+// Clear removes every item from the stack.
+func (s *Stack[T]) Clear() {
+	clear(s.items) // Release references for the garbage collector
+	s.items = s.items[:0]
+}
+
+// ToSlice returns a copy of the stack's items ordered from bottom to top.
+func (s *Stack[T]) ToSlice() []T {
+	return append([]T(nil), s.items...)
+}
+
+// SyncStack is a Stack that is safe for concurrent use by multiple goroutines.
+// The zero value is an empty stack ready to use.
+type SyncStack[T any] struct {
+	mu    sync.Mutex
+	stack Stack[T]
+}
+
+// Push adds item to the top of the stack.
+func (s *SyncStack[T]) Push(item T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stack.Push(item)
+}
+
+// Pop removes and returns the top item, or false if the stack is empty.
+func (s *SyncStack[T]) Pop() (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stack.Pop()
+}
+
+// IsEmpty reports whether the stack has no items.
+func (s *SyncStack[T]) IsEmpty() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stack.IsEmpty()
+}
+
+// Size returns the number of items on the stack.
+func (s *SyncStack[T]) Size() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stack.Size()
+}
+
+// Queue is a generic first-in, first-out queue.
+// Dequeued slots are reclaimed in bulk, so Dequeue is amortized O(1)
+// rather than shifting the whole slice each time.
+type Queue[T any] struct {
+	items []T
+	head  int // Index of the front element in items
+}
+
+// Enqueue adds item to the back of the queue.
+func (q *Queue[T]) Enqueue(item T) {
+	q.items = append(q.items, item)
+}
+
+// Dequeue removes and returns the front item, or false if the queue is empty.
+func (q *Queue[T]) Dequeue() (T, bool) {
+	var zero T
+	if q.IsEmpty() {
+		return zero, false
+	}
+
+	item := q.items[q.head]
+	q.items[q.head] = zero // Release the reference for the garbage collector
+	q.head++
+
+	// Reclaim the dequeued prefix once it makes up half the slice
+	if q.head*2 >= len(q.items) {
+		n := copy(q.items, q.items[q.head:])
+		clear(q.items[n:])
+		q.items = q.items[:n]
+		q.head = 0
+	}
+
+	return item, true
+}
+
+// Peek returns the front item without removing it, or false if the queue is empty.
+func (q *Queue[T]) Peek() (T, bool) {
+	var zero T
+	if q.IsEmpty() {
+		return zero, false
+	}
+	return q.items[q.head], true
+}
+
+// Len returns the number of items in the queue.
+func (q *Queue[T]) Len() int {
+	return len(q.items) - q.head
+}
+
+// IsEmpty reports whether the queue has no items.
+func (q *Queue[T]) IsEmpty() bool {
+	return q.Len() == 0
+}
+
+// Set is a generic collection of unique values.
+// The zero value is an empty set ready to use.
+type Set[T comparable] struct {
+	items map[T]struct{}
+}
+
+// NewSet returns a set holding the given items, with duplicates collapsed.
+func NewSet[T comparable](items ...T) *Set[T] {
+	set := &Set[T]{items: make(map[T]struct{}, len(items))}
+	for _, item := range items {
+		set.Add(item)
+	}
+	return set
+}
+
+// Add inserts item into the set. Adding an existing item has no effect.
+func (s *Set[T]) Add(item T) {
+	if s.items == nil {
+		s.items = make(map[T]struct{})
+	}
+	s.items[item] = struct{}{}
+}
+
+// Remove deletes item from the set if present.
+func (s *Set[T]) Remove(item T) {
+	delete(s.items, item)
+}
+
+// Contains reports whether item is in the set.
+func (s *Set[T]) Contains(item T) bool {
+	_, ok := s.items[item]
+	return ok
+}
+
+// Len returns the number of items in the set.
+func (s *Set[T]) Len() int {
+	return len(s.items)
+}
+
+// Union returns a new set holding the items of both s and other.
+func (s *Set[T]) Union(other *Set[T]) *Set[T] {
+	result := &Set[T]{items: make(map[T]struct{}, s.Len()+other.Len())}
+	for item := range s.items {
+		result.Add(item)
+	}
+	for item := range other.items {
+		result.Add(item)
+	}
+	return result
+}
+
+// Intersect returns a new set holding the items present in both s and other.
+func (s *Set[T]) Intersect(other *Set[T]) *Set[T] {
+	// Iterate over the smaller set
+	small, large := s, other
+	if small.Len() > large.Len() {
+		small, large = large, small
+	}
+
+	result := &Set[T]{items: make(map[T]struct{})}
+	for item := range small.items {
+		if large.Contains(item) {
+			result.Add(item)
+		}
+	}
+	return result
+}
+
+// ToSlice returns the items of the set in unspecified order.
+func (s *Set[T]) ToSlice() []T {
+	items := make([]T, 0, len(s.items))
+	for item := range s.items {
+		items = append(items, item)
+	}
+	return items
+}
+
+// LRU is a fixed-capacity cache that evicts the least recently used entry.
+// Get and Put run in O(1) using a map into a doubly linked list ordered from
+// most to least recently used. It is not safe for concurrent use.
+type LRU[K comparable, V any] struct {
+	capacity int
+	items    map[K]*list.Element
+	order    *list.List // Front is most recently used
+}
+
+// lruEntry is the value stored in each list element.
+type lruEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// NewLRU returns an empty cache holding at most capacity entries.
+// A capacity below 1 is treated as 1.
+func NewLRU[K comparable, V any](capacity int) *LRU[K, V] {
+	return &LRU[K, V]{
+		capacity: max(capacity, 1),
+		items:    make(map[K]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// Get returns the value cached for key and marks it most recently used.
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	elem, ok := c.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*lruEntry[K, V]).value, true
+}
+
+// Put stores value under key and marks it most recently used, evicting the
+// least recently used entry if the cache is over capacity.
+func (c *LRU[K, V]) Put(key K, value V) {
+	if elem, ok := c.items[key]; ok {
+		elem.Value.(*lruEntry[K, V]).value = value
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.items[key] = c.order.PushFront(&lruEntry[K, V]{key: key, value: value})
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruEntry[K, V]).key)
+	}
+}
+
+// Len returns the number of cached entries.
+func (c *LRU[K, V]) Len() int {
+	return c.order.Len()
+}
+
 // Numeric Constraint interfaces
 type Numeric interface {
 	~int | ~int8 | ~int16 | ~int32 | ~int64 |
@@ -99,6 +494,109 @@ func maxExample[T Numeric](a, b T) T {
 	return b
 }
 
+// Clamp restricts v to the inclusive range [lo, hi].
+// If lo > hi the bounds are swapped, so Clamp(v, 10, 0) behaves like Clamp(v, 0, 10).
+func Clamp[T Numeric](v, lo, hi T) T {
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
+
+// InRange reports whether v lies within the inclusive range [lo, hi].
+// Like Clamp, it swaps the bounds when lo > hi.
+func InRange[T Numeric](v, lo, hi T) bool {
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	return v >= lo && v <= hi
+}
+
+// Optional holds either a value of type T or nothing.
+// It is a type-safe alternative to returning (value, ok) pairs.
+type Optional[T any] struct {
+	value   T
+	present bool
+}
+
+// Some returns an Optional holding v.
+func Some[T any](v T) Optional[T] {
+	return Optional[T]{value: v, present: true}
+}
+
+// None returns an empty Optional.
+func None[T any]() Optional[T] {
+	return Optional[T]{}
+}
+
+// IsPresent reports whether the Optional holds a value.
+func (o Optional[T]) IsPresent() bool {
+	return o.present
+}
+
+// Get returns the held value and whether it was present.
+func (o Optional[T]) Get() (T, bool) {
+	return o.value, o.present
+}
+
+// OrElse returns the held value, or def if the Optional is empty.
+func (o Optional[T]) OrElse(def T) T {
+	if o.present {
+		return o.value
+	}
+	return def
+}
+
+// Result holds either a value of type T or the error that prevented producing it.
+// It is the (value, error) counterpart of Optional.
+type Result[T any] struct {
+	value T
+	err   error
+}
+
+// Ok returns a successful Result holding v.
+func Ok[T any](v T) Result[T] {
+	return Result[T]{value: v}
+}
+
+// Err returns a failed Result carrying err.
+func Err[T any](err error) Result[T] {
+	return Result[T]{err: err}
+}
+
+// IsOk reports whether the Result holds a value rather than an error.
+func (r Result[T]) IsOk() bool {
+	return r.err == nil
+}
+
+// Get returns the held value and error in the usual Go order.
+func (r Result[T]) Get() (T, error) {
+	return r.value, r.err
+}
+
+// OrElse returns the held value, or def if the Result is an error.
+func (r Result[T]) OrElse(def T) T {
+	if r.err != nil {
+		return def
+	}
+	return r.value
+}
+
+// SafeDivide returns a / b, or None when b is zero.
+// Integer instantiations truncate just like the / operator.
+func SafeDivide[T Numeric](a, b T) Optional[T] {
+	if b == 0 {
+		return None[T]()
+	}
+	return Some(a / b)
+}
+
 // Stringer Generic with method constraint
 type Stringer interface {
 	String() string
@@ -145,6 +643,47 @@ type Ordered interface {
 		~float32 | ~float64 | ~string
 }
 
+// MinSlice returns the smallest element of s, or false if s is empty.
+func MinSlice[T Ordered](s []T) (T, bool) {
+	var result T
+	if len(s) == 0 {
+		return result, false
+	}
+
+	result = s[0]
+	for _, v := range s[1:] {
+		if v < result {
+			result = v
+		}
+	}
+	return result, true
+}
+
+// MaxSlice returns the largest element of s, or false if s is empty.
+func MaxSlice[T Ordered](s []T) (T, bool) {
+	var result T
+	if len(s) == 0 {
+		return result, false
+	}
+
+	result = s[0]
+	for _, v := range s[1:] {
+		if v > result {
+			result = v
+		}
+	}
+	return result, true
+}
+
+// SumSlice returns the sum of the elements of s, or zero for an empty slice.
+func SumSlice[T Numeric](s []T) T {
+	var total T
+	for _, v := range s {
+		total += v
+	}
+	return total
+}
+
 func sort3[T Ordered](a, b, c T) (T, T, T) {
 	if a > b {
 		a, b = b, a
@@ -158,6 +697,14 @@ func sort3[T Ordered](a, b, c T) (T, T, T) {
 	return a, b, c
 }
 
+// SortBy sorts s in place by the ordered key keyFn derives from each element.
+// Like sort.Slice, the sort is not stable: elements with equal keys may be reordered.
+func SortBy[T any, K Ordered](s []T, keyFn func(T) K) {
+	sort.Slice(s, func(i, j int) bool {
+		return keyFn(s[i]) < keyFn(s[j])
+	})
+}
+
 func genericsExample() {
 	fmt.Println("=== Basic Generics ===")
 
@@ -184,6 +731,34 @@ func genericsExample() {
 	doubled := mapSlice(numbers, func(n int) int { return n * 2 })
 	fmt.Printf("Doubled: %v\n", doubled)
 
+	isEven := func(n int) bool { return n%2 == 0 }
+	fmt.Printf("Count even: %d\n", Count(numbers, isEven))
+	if firstBig, ok := Find(numbers, func(n int) bool { return n > 7 }); ok {
+		fmt.Printf("First > 7: %d\n", firstBig)
+	}
+	fmt.Printf("All positive: %t, Any > 100: %t\n",
+		All(numbers, func(n int) bool { return n > 0 }), Any(numbers, func(n int) bool { return n > 100 }))
+
+	small, large := Partition(numbers, func(n int) bool { return n <= 5 })
+	fmt.Printf("Partitioned: small=%v large=%v\n", small, large)
+
+	sum := Reduce(numbers, 0, func(acc, n int) int { return acc + n })
+	fmt.Printf("Sum: %d\n", sum)
+
+	byParity := GroupBy(numbers, func(n int) string {
+		if n%2 == 0 {
+			return "even"
+		}
+		return "odd"
+	})
+	fmt.Printf("Grouped by parity: even=%v odd=%v\n", byParity["even"], byParity["odd"])
+
+	fmt.Printf("Distinct: %v\n", Distinct([]int{3, 1, 3, 2, 1}))
+	fmt.Printf("Chunks of 4: %v\n", Chunk(numbers, 4))
+	for _, pair := range Zip([]string{"a", "b", "c"}, numbers) {
+		fmt.Printf("Zipped: %s=%d\n", pair.First, pair.Second)
+	}
+
 	fmt.Println("\n=== Generic Data Structures ===")
 
 	// Integer stack
@@ -193,6 +768,9 @@ func genericsExample() {
 	intStack.Push(3)
 
 	fmt.Printf("Stack size: %d\n", intStack.Size())
+	if top, ok := intStack.Peek(); ok {
+		fmt.Printf("Stack top: %d (contents %v)\n", top, intStack.ToSlice())
+	}
 	for !intStack.IsEmpty() {
 		if item, ok := intStack.Pop(); ok {
 			fmt.Printf("Popped: %d\n", item)
@@ -208,9 +786,65 @@ func genericsExample() {
 		fmt.Printf("Popped string: %s\n", item)
 	}
 
+	// String queue
+	queue := &Queue[string]{}
+	queue.Enqueue("first")
+	queue.Enqueue("second")
+	queue.Enqueue("third")
+
+	fmt.Printf("Queue length: %d\n", queue.Len())
+	for !queue.IsEmpty() {
+		if item, ok := queue.Dequeue(); ok {
+			fmt.Printf("Dequeued: %s\n", item)
+		}
+	}
+
+	// Integer sets
+	odds := NewSet(1, 3, 5, 7, 9)
+	primes := NewSet(2, 3, 5, 7)
+	common := odds.Intersect(primes).ToSlice()
+	sort.Ints(common)
+	fmt.Printf("Union size: %d\n", odds.Union(primes).Len())
+	fmt.Printf("Odd primes: %v\n", common)
+
+	// LRU cache
+	cache := NewLRU[string, int](2)
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Get("a") // "a" is now most recently used
+	cache.Put("c", 3)
+	if _, ok := cache.Get("b"); !ok {
+		fmt.Println("LRU evicted: b")
+	}
+
 	fmt.Println("\n=== Numeric Constraints ===")
 	fmt.Printf("minExample(5, 3) = %d\n", minExample(5, 3))
 	fmt.Printf("maxExample(2.5, 7.1) = %.1f\n", maxExample(2.5, 7.1))
+	fmt.Printf("Clamp(150, 0, 100) = %d\n", Clamp(150, 0, 100))
+	fmt.Printf("Clamp(-0.5, 0.0, 1.0) = %.1f\n", Clamp(-0.5, 0.0, 1.0))
+	fmt.Printf("InRange(42, 1, 100) = %t\n", InRange(42, 1, 100))
+	fmt.Printf("InRange(42, 100, 1) = %t (bounds swapped)\n", InRange(42, 100, 1))
+	if lowest, ok := MinSlice(numbers); ok {
+		fmt.Printf("MinSlice(numbers) = %d\n", lowest)
+	}
+	if highest, ok := MaxSlice([]string{"pear", "apple", "plum"}); ok {
+		fmt.Printf("MaxSlice(fruits) = %s\n", highest)
+	}
+	fmt.Printf("SumSlice(numbers) = %d\n", SumSlice(numbers))
+
+	fmt.Println("\n=== Optional Results ===")
+	fmt.Printf("SafeDivide(7.0, 2.0).OrElse(0) = %.1f\n", SafeDivide(7.0, 2.0).OrElse(0))
+	fmt.Printf("SafeDivide(7, 2).OrElse(0) = %d\n", SafeDivide(7, 2).OrElse(0))
+	fmt.Printf("SafeDivide(7, 0).OrElse(-1) = %d\n", SafeDivide(7, 0).OrElse(-1))
+	if _, ok := SafeDivide(1.0, 0.0).Get(); !ok {
+		fmt.Println("SafeDivide(1.0, 0.0) is None")
+	}
+
+	parsed := Err[int](strconv.ErrSyntax)
+	if n, err := strconv.Atoi("42"); err == nil {
+		parsed = Ok(n)
+	}
+	fmt.Printf("Result(Atoi(\"42\")).OrElse(0) = %d\n", parsed.OrElse(0))
 
 	fmt.Println("\n=== Interface Constraints ===")
 	products := []Product{
@@ -220,6 +854,9 @@ func genericsExample() {
 	}
 	printStrings(products)
 
+	SortBy(products, func(p Product) float64 { return p.Price })
+	fmt.Printf("Cheapest product: %s\n", products[0].Name)
+
 	fmt.Println("\n=== Ordered Types ===")
 	a, b, c := sort3(3, 1, 2)
 	fmt.Printf("sort3(3, 1, 2) = %d, %d, %d\n", a, b, c)
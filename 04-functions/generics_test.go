@@ -0,0 +1,747 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+)
+
+func TestClamp(t *testing.T) {
+	tests := []struct {
+		v, lo, hi, expected int
+	}{
+		{5, 0, 10, 5},
+		{-3, 0, 10, 0},
+		{15, 0, 10, 10},
+		{15, 10, 0, 10}, // bounds swapped
+	}
+
+	for _, test := range tests {
+		result := Clamp(test.v, test.lo, test.hi)
+		if result != test.expected {
+			t.Errorf("Clamp(%d, %d, %d) = %d; want %d", test.v, test.lo, test.hi, result, test.expected)
+		}
+	}
+
+	if result := Clamp(1.5, 0.0, 1.0); result != 1.0 {
+		t.Errorf("Clamp(1.5, 0.0, 1.0) = %f; want 1.0", result)
+	}
+}
+
+func TestInRange(t *testing.T) {
+	tests := []struct {
+		v, lo, hi int
+		expected  bool
+	}{
+		{5, 0, 10, true},
+		{0, 0, 10, true},
+		{10, 0, 10, true},
+		{11, 0, 10, false},
+		{5, 10, 0, true}, // bounds swapped
+	}
+
+	for _, test := range tests {
+		result := InRange(test.v, test.lo, test.hi)
+		if result != test.expected {
+			t.Errorf("InRange(%d, %d, %d) = %t; want %t", test.v, test.lo, test.hi, result, test.expected)
+		}
+	}
+}
+
+func TestSafeDivide(t *testing.T) {
+	if result, ok := SafeDivide(7, 2).Get(); !ok || result != 3 {
+		t.Errorf("SafeDivide(7, 2) = (%d, %t); want (3, true)", result, ok)
+	}
+
+	if result, ok := SafeDivide(7.0, 2.0).Get(); !ok || result != 3.5 {
+		t.Errorf("SafeDivide(7.0, 2.0) = (%f, %t); want (3.5, true)", result, ok)
+	}
+
+	if SafeDivide(7, 0).IsPresent() {
+		t.Error("SafeDivide(7, 0) should be None")
+	}
+
+	if result := SafeDivide(7.0, 0.0).OrElse(-1); result != -1 {
+		t.Errorf("SafeDivide(7.0, 0.0).OrElse(-1) = %f; want -1", result)
+	}
+}
+
+func TestOptional(t *testing.T) {
+	some := Some("value")
+	if !some.IsPresent() {
+		t.Error("Some(\"value\").IsPresent() = false; want true")
+	}
+	if v, ok := some.Get(); v != "value" || !ok {
+		t.Errorf("Some(\"value\").Get() = (%q, %t); want (\"value\", true)", v, ok)
+	}
+	if v := some.OrElse("fallback"); v != "value" {
+		t.Errorf("Some(\"value\").OrElse(\"fallback\") = %q; want \"value\"", v)
+	}
+
+	// A present zero value is still present
+	if !Some(0).IsPresent() {
+		t.Error("Some(0).IsPresent() = false; want true")
+	}
+
+	none := None[string]()
+	if none.IsPresent() {
+		t.Error("None().IsPresent() = true; want false")
+	}
+	if v, ok := none.Get(); v != "" || ok {
+		t.Errorf("None().Get() = (%q, %t); want (\"\", false)", v, ok)
+	}
+	if v := none.OrElse("fallback"); v != "fallback" {
+		t.Errorf("None().OrElse(\"fallback\") = %q; want \"fallback\"", v)
+	}
+
+	var zero Optional[int]
+	if zero.IsPresent() {
+		t.Error("zero Optional is present; want None")
+	}
+}
+
+func TestResult(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	ok := Ok(42)
+	if !ok.IsOk() {
+		t.Error("Ok(42).IsOk() = false; want true")
+	}
+	if v, err := ok.Get(); v != 42 || err != nil {
+		t.Errorf("Ok(42).Get() = (%d, %v); want (42, nil)", v, err)
+	}
+	if v := ok.OrElse(-1); v != 42 {
+		t.Errorf("Ok(42).OrElse(-1) = %d; want 42", v)
+	}
+
+	failed := Err[int](errFailed)
+	if failed.IsOk() {
+		t.Error("Err(...).IsOk() = true; want false")
+	}
+	if v, err := failed.Get(); v != 0 || !errors.Is(err, errFailed) {
+		t.Errorf("Err(...).Get() = (%d, %v); want (0, %v)", v, err, errFailed)
+	}
+	if v := failed.OrElse(-1); v != -1 {
+		t.Errorf("Err(...).OrElse(-1) = %d; want -1", v)
+	}
+}
+
+func TestReduce(t *testing.T) {
+	sum := func(acc, n int) int { return acc + n }
+
+	tests := []struct {
+		input    []int
+		initial  int
+		expected int
+	}{
+		{[]int{1, 2, 3, 4}, 0, 10},
+		{[]int{1, 2, 3, 4}, 100, 110},
+		{[]int{}, 7, 7},
+		{nil, 7, 7},
+	}
+
+	for _, test := range tests {
+		result := Reduce(test.input, test.initial, sum)
+		if result != test.expected {
+			t.Errorf("Reduce(%v, %d, sum) = %d; want %d", test.input, test.initial, result, test.expected)
+		}
+	}
+
+	// Accumulation is left to right
+	concat := Reduce([]string{"a", "b", "c"}, ">", func(acc, s string) string { return acc + s })
+	if concat != ">abc" {
+		t.Errorf("Reduce(strings, concat) = %q; want %q", concat, ">abc")
+	}
+
+	// The accumulator type may differ from the element type
+	lengths := Reduce([]string{"go", "fast"}, 0, func(acc int, s string) int { return acc + len(s) })
+	if lengths != 6 {
+		t.Errorf("Reduce(strings, total length) = %d; want 6", lengths)
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	parity := GroupBy([]int{1, 2, 3, 4, 5, 6, 7}, func(n int) bool { return n%2 == 0 })
+
+	if !reflect.DeepEqual(parity[true], []int{2, 4, 6}) {
+		t.Errorf("GroupBy evens = %v; want [2 4 6]", parity[true])
+	}
+	if !reflect.DeepEqual(parity[false], []int{1, 3, 5, 7}) {
+		t.Errorf("GroupBy odds = %v; want [1 3 5 7]", parity[false])
+	}
+
+	type employee struct {
+		Name string
+		Team string
+	}
+	staff := []employee{
+		{"Ana", "platform"},
+		{"Ben", "mobile"},
+		{"Cy", "platform"},
+		{"Dee", "platform"},
+	}
+
+	byTeam := GroupBy(staff, func(e employee) string { return e.Team })
+	if len(byTeam) != 2 {
+		t.Errorf("GroupBy by team returned %d groups; want 2", len(byTeam))
+	}
+	wantPlatform := []employee{{"Ana", "platform"}, {"Cy", "platform"}, {"Dee", "platform"}}
+	if !reflect.DeepEqual(byTeam["platform"], wantPlatform) {
+		t.Errorf("GroupBy platform = %v; want %v", byTeam["platform"], wantPlatform)
+	}
+
+	if empty := GroupBy([]int{}, func(n int) int { return n }); len(empty) != 0 {
+		t.Errorf("GroupBy(empty) = %v; want no groups", empty)
+	}
+}
+
+func TestKeysAndValues(t *testing.T) {
+	stock := map[string]int{"apple": 3, "banana": 0, "cherry": 12}
+
+	keys := Keys(stock)
+	sort.Strings(keys)
+	if !reflect.DeepEqual(keys, []string{"apple", "banana", "cherry"}) {
+		t.Errorf("Keys(stock) = %v; want [apple banana cherry]", keys)
+	}
+
+	values := Values(stock)
+	sort.Ints(values)
+	if !reflect.DeepEqual(values, []int{0, 3, 12}) {
+		t.Errorf("Values(stock) = %v; want [0 3 12]", values)
+	}
+
+	empty := map[string]int{}
+	if k := Keys(empty); k == nil || len(k) != 0 {
+		t.Errorf("Keys(empty) = %#v; want empty non-nil slice", k)
+	}
+	if v := Values(empty); v == nil || len(v) != 0 {
+		t.Errorf("Values(empty) = %#v; want empty non-nil slice", v)
+	}
+}
+
+func TestQueueFIFO(t *testing.T) {
+	queue := &Queue[int]{}
+
+	if _, ok := queue.Dequeue(); ok {
+		t.Error("Dequeue on empty queue returned ok")
+	}
+	if _, ok := queue.Peek(); ok {
+		t.Error("Peek on empty queue returned ok")
+	}
+	if !queue.IsEmpty() || queue.Len() != 0 {
+		t.Errorf("new queue: IsEmpty = %t, Len = %d; want true, 0", queue.IsEmpty(), queue.Len())
+	}
+
+	// Interleave operations so the internal slice is compacted several times
+	next := 0
+	for i := 0; i < 100; i++ {
+		queue.Enqueue(i)
+		if i%3 == 2 {
+			item, ok := queue.Dequeue()
+			if !ok || item != next {
+				t.Fatalf("Dequeue = (%d, %t); want (%d, true)", item, ok, next)
+			}
+			next++
+		}
+	}
+
+	if queue.Len() != 100-next {
+		t.Errorf("Len = %d; want %d", queue.Len(), 100-next)
+	}
+
+	if item, ok := queue.Peek(); !ok || item != next {
+		t.Errorf("Peek = (%d, %t); want (%d, true)", item, ok, next)
+	}
+	if queue.Len() != 100-next {
+		t.Errorf("Len after Peek = %d; want %d", queue.Len(), 100-next)
+	}
+
+	for ; next < 100; next++ {
+		item, ok := queue.Dequeue()
+		if !ok || item != next {
+			t.Fatalf("Dequeue = (%d, %t); want (%d, true)", item, ok, next)
+		}
+	}
+
+	if !queue.IsEmpty() {
+		t.Errorf("queue not empty after dequeuing everything; Len = %d", queue.Len())
+	}
+	if _, ok := queue.Dequeue(); ok {
+		t.Error("Dequeue on drained queue returned ok")
+	}
+}
+
+func TestSet(t *testing.T) {
+	set := &Set[string]{}
+	set.Add("go")
+	set.Add("rust")
+	set.Add("go") // Repeated adds are collapsed
+
+	if set.Len() != 2 {
+		t.Errorf("Len = %d; want 2", set.Len())
+	}
+	if !set.Contains("go") || set.Contains("zig") {
+		t.Errorf("Contains(go) = %t, Contains(zig) = %t; want true, false", set.Contains("go"), set.Contains("zig"))
+	}
+
+	set.Remove("go")
+	set.Remove("zig") // Removing a missing item is a no-op
+	if set.Contains("go") || set.Len() != 1 {
+		t.Errorf("after Remove: Contains(go) = %t, Len = %d; want false, 1", set.Contains("go"), set.Len())
+	}
+}
+
+func TestSetUnionIntersect(t *testing.T) {
+	tests := []struct {
+		name      string
+		a, b      []int
+		union     []int
+		intersect []int
+	}{
+		{"overlapping", []int{1, 2, 3}, []int{2, 3, 4}, []int{1, 2, 3, 4}, []int{2, 3}},
+		{"disjoint", []int{1, 2}, []int{3, 4}, []int{1, 2, 3, 4}, []int{}},
+		{"subset", []int{1, 2, 3}, []int{2}, []int{1, 2, 3}, []int{2}},
+		{"duplicates", []int{1, 1, 2}, []int{2, 2}, []int{1, 2}, []int{2}},
+		{"empty", []int{}, []int{1}, []int{1}, []int{}},
+	}
+
+	for _, test := range tests {
+		a, b := NewSet(test.a...), NewSet(test.b...)
+
+		union := a.Union(b).ToSlice()
+		sort.Ints(union)
+		if !reflect.DeepEqual(union, test.union) {
+			t.Errorf("%s: Union = %v; want %v", test.name, union, test.union)
+		}
+
+		intersect := a.Intersect(b).ToSlice()
+		sort.Ints(intersect)
+		if !reflect.DeepEqual(intersect, test.intersect) {
+			t.Errorf("%s: Intersect = %v; want %v", test.name, intersect, test.intersect)
+		}
+
+		// Operands are left untouched
+		if a.Len() != NewSet(test.a...).Len() || b.Len() != NewSet(test.b...).Len() {
+			t.Errorf("%s: Union/Intersect mutated their operands", test.name)
+		}
+	}
+}
+
+func TestMinMaxSlice(t *testing.T) {
+	ints := []struct {
+		input    []int
+		min, max int
+		ok       bool
+	}{
+		{[]int{3, 1, 4, 1, 5}, 1, 5, true},
+		{[]int{-2, -7, -1}, -7, -1, true},
+		{[]int{42}, 42, 42, true},
+		{[]int{}, 0, 0, false},
+		{nil, 0, 0, false},
+	}
+
+	for _, test := range ints {
+		minV, minOK := MinSlice(test.input)
+		if minV != test.min || minOK != test.ok {
+			t.Errorf("MinSlice(%v) = (%d, %t); want (%d, %t)", test.input, minV, minOK, test.min, test.ok)
+		}
+		maxV, maxOK := MaxSlice(test.input)
+		if maxV != test.max || maxOK != test.ok {
+			t.Errorf("MaxSlice(%v) = (%d, %t); want (%d, %t)", test.input, maxV, maxOK, test.max, test.ok)
+		}
+	}
+
+	floats := []float64{2.5, -0.5, 9.75}
+	if got, ok := MinSlice(floats); got != -0.5 || !ok {
+		t.Errorf("MinSlice(%v) = (%g, %t); want (-0.5, true)", floats, got, ok)
+	}
+	if got, ok := MaxSlice(floats); got != 9.75 || !ok {
+		t.Errorf("MaxSlice(%v) = (%g, %t); want (9.75, true)", floats, got, ok)
+	}
+
+	words := []string{"pear", "apple", "plum"}
+	if got, ok := MinSlice(words); got != "apple" || !ok {
+		t.Errorf("MinSlice(%v) = (%q, %t); want (\"apple\", true)", words, got, ok)
+	}
+	if got, ok := MaxSlice(words); got != "plum" || !ok {
+		t.Errorf("MaxSlice(%v) = (%q, %t); want (\"plum\", true)", words, got, ok)
+	}
+	if got, ok := MinSlice([]string{}); got != "" || ok {
+		t.Errorf("MinSlice([]) = (%q, %t); want (\"\", false)", got, ok)
+	}
+}
+
+func TestSumSlice(t *testing.T) {
+	tests := []struct {
+		input    []int
+		expected int
+	}{
+		{[]int{1, 2, 3, 4}, 10},
+		{[]int{-5, 5}, 0},
+		{[]int{}, 0},
+		{nil, 0},
+	}
+
+	for _, test := range tests {
+		if got := SumSlice(test.input); got != test.expected {
+			t.Errorf("SumSlice(%v) = %d; want %d", test.input, got, test.expected)
+		}
+	}
+
+	floats := []float64{0.5, 1.25, 2}
+	if got := SumSlice(floats); got != 3.75 {
+		t.Errorf("SumSlice(%v) = %g; want 3.75", floats, got)
+	}
+}
+
+func TestStackPeekClearToSlice(t *testing.T) {
+	stack := &Stack[int]{}
+
+	if _, ok := stack.Peek(); ok {
+		t.Error("Peek on empty stack returned ok")
+	}
+	if got := stack.ToSlice(); len(got) != 0 {
+		t.Errorf("ToSlice on empty stack = %v; want []", got)
+	}
+
+	stack.Push(1)
+	stack.Push(2)
+	stack.Push(3)
+
+	// Peek must not remove the top item
+	for i := 0; i < 2; i++ {
+		if top, ok := stack.Peek(); top != 3 || !ok {
+			t.Errorf("Peek = (%d, %t); want (3, true)", top, ok)
+		}
+	}
+	if stack.Size() != 3 {
+		t.Errorf("Size after Peek = %d; want 3", stack.Size())
+	}
+
+	snapshot := stack.ToSlice()
+	if !reflect.DeepEqual(snapshot, []int{1, 2, 3}) {
+		t.Errorf("ToSlice = %v; want [1 2 3]", snapshot)
+	}
+
+	// The snapshot is independent of the stack in both directions
+	snapshot[0] = 99
+	stack.Push(4)
+	if !reflect.DeepEqual(stack.ToSlice(), []int{1, 2, 3, 4}) {
+		t.Errorf("ToSlice after mutating snapshot = %v; want [1 2 3 4]", stack.ToSlice())
+	}
+	if len(snapshot) != 3 {
+		t.Errorf("snapshot length = %d after Push; want 3", len(snapshot))
+	}
+
+	stack.Clear()
+	if !stack.IsEmpty() || stack.Size() != 0 {
+		t.Errorf("after Clear: IsEmpty = %t, Size = %d; want true, 0", stack.IsEmpty(), stack.Size())
+	}
+	if _, ok := stack.Pop(); ok {
+		t.Error("Pop after Clear returned ok")
+	}
+
+	stack.Push(5)
+	if top, ok := stack.Peek(); top != 5 || !ok {
+		t.Errorf("Peek after Clear and Push = (%d, %t); want (5, true)", top, ok)
+	}
+}
+
+func TestSyncStackConcurrent(t *testing.T) {
+	const (
+		workers = 8
+		perWork = 500
+	)
+
+	stack := &SyncStack[int]{}
+	var popped sync.Map
+	var wg sync.WaitGroup
+
+	// Pushers and poppers run at the same time; run with -race to check locking
+	for w := 0; w < workers; w++ {
+		wg.Add(2)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWork; i++ {
+				stack.Push(w*perWork + i)
+			}
+		}(w)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perWork/2; i++ {
+				if item, ok := stack.Pop(); ok {
+					popped.Store(item, true)
+				}
+				_ = stack.Size()
+				_ = stack.IsEmpty()
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Drain the rest; every pushed value must come out exactly once
+	for {
+		item, ok := stack.Pop()
+		if !ok {
+			break
+		}
+		if _, dup := popped.LoadOrStore(item, true); dup {
+			t.Fatalf("item %d popped twice", item)
+		}
+	}
+
+	count := 0
+	popped.Range(func(_, _ any) bool {
+		count++
+		return true
+	})
+	if count != workers*perWork {
+		t.Errorf("popped %d distinct items; want %d", count, workers*perWork)
+	}
+	if !stack.IsEmpty() || stack.Size() != 0 {
+		t.Errorf("after draining: IsEmpty = %t, Size = %d; want true, 0", stack.IsEmpty(), stack.Size())
+	}
+}
+
+func TestZip(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        []int
+		b        []string
+		expected []Pair[int, string]
+	}{
+		{"equal", []int{1, 2}, []string{"a", "b"}, []Pair[int, string]{{1, "a"}, {2, "b"}}},
+		{"first shorter", []int{1}, []string{"a", "b", "c"}, []Pair[int, string]{{1, "a"}}},
+		{"second shorter", []int{1, 2, 3}, []string{"a", "b"}, []Pair[int, string]{{1, "a"}, {2, "b"}}},
+		{"empty", nil, []string{"a"}, []Pair[int, string]{}},
+	}
+
+	for _, test := range tests {
+		got := Zip(test.a, test.b)
+		if !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("%s: Zip(%v, %v) = %v; want %v", test.name, test.a, test.b, got, test.expected)
+		}
+	}
+}
+
+func TestChunk(t *testing.T) {
+	tests := []struct {
+		input    []int
+		size     int
+		expected [][]int
+	}{
+		{[]int{1, 2, 3, 4, 5, 6}, 2, [][]int{{1, 2}, {3, 4}, {5, 6}}},
+		{[]int{1, 2, 3, 4, 5, 6, 7}, 3, [][]int{{1, 2, 3}, {4, 5, 6}, {7}}},
+		{[]int{1, 2}, 5, [][]int{{1, 2}}},
+		{[]int{}, 3, [][]int{}},
+		{[]int{1, 2, 3}, 0, nil},
+		{[]int{1, 2, 3}, -1, nil},
+	}
+
+	for _, test := range tests {
+		got := Chunk(test.input, test.size)
+		if !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("Chunk(%v, %d) = %v; want %v", test.input, test.size, got, test.expected)
+		}
+	}
+
+	// Appending to one chunk must not overwrite the next
+	input := []int{1, 2, 3, 4}
+	chunks := Chunk(input, 2)
+	_ = append(chunks[0], 99)
+	if !reflect.DeepEqual(chunks[1], []int{3, 4}) {
+		t.Errorf("append to first chunk changed second chunk to %v", chunks[1])
+	}
+}
+
+func TestDistinct(t *testing.T) {
+	tests := []struct {
+		input    []string
+		expected []string
+	}{
+		{[]string{"b", "a", "b", "c", "a"}, []string{"b", "a", "c"}},
+		{[]string{"x", "y", "z"}, []string{"x", "y", "z"}},
+		{[]string{"same", "same", "same"}, []string{"same"}},
+		{[]string{}, []string{}},
+		{nil, []string{}},
+	}
+
+	for _, test := range tests {
+		original := append([]string(nil), test.input...)
+
+		got := Distinct(test.input)
+		if !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("Distinct(%v) = %v; want %v", test.input, got, test.expected)
+		}
+		if len(test.input) > 0 && !reflect.DeepEqual(test.input, original) {
+			t.Errorf("Distinct mutated its input: got %v; want %v", test.input, original)
+		}
+	}
+}
+
+func TestSortBy(t *testing.T) {
+	type employee struct {
+		Name string
+		Age  int
+	}
+
+	staff := []employee{
+		{"Carol", 41},
+		{"alice", 29},
+		{"Bob", 35},
+		{"Dave", 22},
+	}
+
+	SortBy(staff, func(e employee) int { return e.Age })
+	wantByAge := []employee{{"Dave", 22}, {"alice", 29}, {"Bob", 35}, {"Carol", 41}}
+	if !reflect.DeepEqual(staff, wantByAge) {
+		t.Errorf("SortBy(age) = %v; want %v", staff, wantByAge)
+	}
+
+	// String keys compare byte-wise, so uppercase sorts before lowercase
+	SortBy(staff, func(e employee) string { return e.Name })
+	wantByName := []employee{{"Bob", 35}, {"Carol", 41}, {"Dave", 22}, {"alice", 29}}
+	if !reflect.DeepEqual(staff, wantByName) {
+		t.Errorf("SortBy(name) = %v; want %v", staff, wantByName)
+	}
+
+	var empty []employee
+	SortBy(empty, func(e employee) int { return e.Age })
+	if len(empty) != 0 {
+		t.Errorf("SortBy(nil) = %v; want empty", empty)
+	}
+}
+
+func TestLRUEviction(t *testing.T) {
+	cache := NewLRU[string, int](2)
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("c", 3) // Evicts "a", the oldest
+
+	if _, ok := cache.Get("a"); ok {
+		t.Error("Get(a) found entry that should have been evicted")
+	}
+	if v, ok := cache.Get("b"); v != 2 || !ok {
+		t.Errorf("Get(b) = (%d, %t); want (2, true)", v, ok)
+	}
+	if v, ok := cache.Get("c"); v != 3 || !ok {
+		t.Errorf("Get(c) = (%d, %t); want (3, true)", v, ok)
+	}
+	if cache.Len() != 2 {
+		t.Errorf("Len = %d; want 2", cache.Len())
+	}
+}
+
+func TestLRUGetPromotes(t *testing.T) {
+	cache := NewLRU[string, int](2)
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Get("a")    // "b" is now least recently used
+	cache.Put("c", 3) // Evicts "b"
+
+	if _, ok := cache.Get("b"); ok {
+		t.Error("Get(b) found entry that should have been evicted")
+	}
+	if v, ok := cache.Get("a"); v != 1 || !ok {
+		t.Errorf("Get(a) = (%d, %t); want (1, true)", v, ok)
+	}
+}
+
+func TestLRUPutUpdates(t *testing.T) {
+	cache := NewLRU[string, int](2)
+	cache.Put("a", 1)
+	cache.Put("b", 2)
+	cache.Put("a", 10) // Updates and promotes "a" without growing the cache
+	cache.Put("c", 3)  // Evicts "b"
+
+	if cache.Len() != 2 {
+		t.Errorf("Len = %d; want 2", cache.Len())
+	}
+	if v, ok := cache.Get("a"); v != 10 || !ok {
+		t.Errorf("Get(a) = (%d, %t); want (10, true)", v, ok)
+	}
+	if _, ok := cache.Get("b"); ok {
+		t.Error("Get(b) found entry that should have been evicted")
+	}
+}
+
+func TestLRUMinimumCapacity(t *testing.T) {
+	cache := NewLRU[int, string](0)
+	cache.Put(1, "one")
+	cache.Put(2, "two")
+
+	if cache.Len() != 1 {
+		t.Errorf("Len = %d; want 1", cache.Len())
+	}
+	if v, ok := cache.Get(2); v != "two" || !ok {
+		t.Errorf("Get(2) = (%q, %t); want (\"two\", true)", v, ok)
+	}
+}
+
+func TestPartition(t *testing.T) {
+	isEven := func(n int) bool { return n%2 == 0 }
+
+	tests := []struct {
+		input   []int
+		matched []int
+		rest    []int
+	}{
+		{[]int{1, 2, 3, 4, 5, 6}, []int{2, 4, 6}, []int{1, 3, 5}},
+		{[]int{7, 3, 8, 1}, []int{8}, []int{7, 3, 1}},
+		{[]int{2, 4}, []int{2, 4}, []int{}},
+		{[]int{1, 3}, []int{}, []int{1, 3}},
+		{[]int{}, []int{}, []int{}},
+		{nil, []int{}, []int{}},
+	}
+
+	for _, test := range tests {
+		matched, rest := Partition(test.input, isEven)
+		if !reflect.DeepEqual(matched, test.matched) || !reflect.DeepEqual(rest, test.rest) {
+			t.Errorf("Partition(%v) = (%v, %v); want (%v, %v)", test.input, matched, rest, test.matched, test.rest)
+		}
+	}
+}
+
+func TestPredicateHelpers(t *testing.T) {
+	isEven := func(n int) bool { return n%2 == 0 }
+
+	tests := []struct {
+		input []int
+		count int
+		find  int
+		found bool
+		all   bool
+		any   bool
+	}{
+		{[]int{1, 2, 3, 4}, 2, 2, true, false, true},
+		{[]int{2, 4, 6}, 3, 2, true, true, true},
+		{[]int{1, 3, 5}, 0, 0, false, false, false},
+		{[]int{}, 0, 0, false, true, false},
+		{nil, 0, 0, false, true, false},
+	}
+
+	for _, test := range tests {
+		if got := Count(test.input, isEven); got != test.count {
+			t.Errorf("Count(%v) = %d; want %d", test.input, got, test.count)
+		}
+		if got, ok := Find(test.input, isEven); got != test.find || ok != test.found {
+			t.Errorf("Find(%v) = (%d, %t); want (%d, %t)", test.input, got, ok, test.find, test.found)
+		}
+		if got := All(test.input, isEven); got != test.all {
+			t.Errorf("All(%v) = %t; want %t", test.input, got, test.all)
+		}
+		if got := Any(test.input, isEven); got != test.any {
+			t.Errorf("Any(%v) = %t; want %t", test.input, got, test.any)
+		}
+	}
+}
+
+func TestFindReturnsFirstMatch(t *testing.T) {
+	words := []string{"go", "gopher", "golang", "rust"}
+	got, ok := Find(words, func(s string) bool { return len(s) > 3 })
+	if got != "gopher" || !ok {
+		t.Errorf("Find(len > 3) = (%q, %t); want (\"gopher\", true)", got, ok)
+	}
+}
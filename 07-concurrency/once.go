@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+)
+
+// KeyedOnce runs an initializer exactly once per key, like a sync.Once per key.
+// Concurrent callers for the same key block until the first call finishes and
+// then share its result. The zero value is ready to use.
+// If fn panics, the key is considered initialized with the zero value, matching sync.Once.
+type KeyedOnce[K comparable, V any] struct {
+	mu      sync.Mutex
+	entries map[K]*onceEntry[V]
+}
+
+type onceEntry[V any] struct {
+	once  sync.Once
+	value V
+}
+
+// Do returns the value for key, calling fn to create it on first use.
+func (k *KeyedOnce[K, V]) Do(key K, fn func() V) V {
+	// The map lock is only held to find the entry, so slow initializers
+	// for different keys run in parallel
+	k.mu.Lock()
+	if k.entries == nil {
+		k.entries = make(map[K]*onceEntry[V])
+	}
+	entry, exists := k.entries[key]
+	if !exists {
+		entry = &onceEntry[V]{}
+		k.entries[key] = entry
+	}
+	k.mu.Unlock()
+
+	entry.once.Do(func() {
+		entry.value = fn()
+	})
+	return entry.value
+}
+
+func keyedOnceExample() {
+	fmt.Println("\n=== Once-per-key Initialization ===")
+
+	var patterns KeyedOnce[string, *regexp.Regexp]
+	compile := func(pattern string) *regexp.Regexp {
+		return patterns.Do(pattern, func() *regexp.Regexp {
+			fmt.Printf("Compiling %q\n", pattern)
+			return regexp.MustCompile(pattern)
+		})
+	}
+
+	var wg sync.WaitGroup
+	inputs := []string{"gopher", "go123", "Gopher", "42"}
+	for _, input := range inputs {
+		wg.Add(1)
+		go func(s string) {
+			defer wg.Done()
+			// Every goroutine shares one compiled regex
+			fmt.Printf("%q lowercase-only: %t\n", s, compile(`^[a-z]+$`).MatchString(s))
+		}(input)
+	}
+	wg.Wait()
+}
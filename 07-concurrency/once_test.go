@@ -0,0 +1,37 @@
+package main
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestKeyedOnceConcurrent(t *testing.T) {
+	var once KeyedOnce[string, int]
+	var calls [3]atomic.Int32
+	keys := []string{"a", "b", "c"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		for k, key := range keys {
+			wg.Add(1)
+			go func(k int, key string) {
+				defer wg.Done()
+				result := once.Do(key, func() int {
+					calls[k].Add(1)
+					return k * 10
+				})
+				if result != k*10 {
+					t.Errorf("Do(%q) = %d; want %d", key, result, k*10)
+				}
+			}(k, key)
+		}
+	}
+	wg.Wait()
+
+	for k, key := range keys {
+		if n := calls[k].Load(); n != 1 {
+			t.Errorf("initializer for %q ran %d times; want 1", key, n)
+		}
+	}
+}
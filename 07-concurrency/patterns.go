@@ -1,7 +1,9 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"strconv"
 	"sync"
 	"time"
 )
@@ -10,16 +12,18 @@ import (
 func workerPoolExample() {
 	fmt.Println("=== Worker Pool Pattern ===")
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
 	jobs := make(chan int, 100)
-	results := make(chan int, 100)
-	var wg sync.WaitGroup
 
 	// Start workers
 	numWorkers := 3
-	for w := 1; w <= numWorkers; w++ {
-		wg.Add(1)
-		go workerPool(w, jobs, results, &wg)
-	}
+	results := WorkerPool(ctx, numWorkers, jobs, func(job int) int {
+		fmt.Printf("Processing job %d\n", job)
+		time.Sleep(100 * time.Millisecond)
+		return job * 2
+	})
 
 	// Send jobs
 	for j := 1; j <= 9; j++ {
@@ -27,24 +31,49 @@ func workerPoolExample() {
 	}
 	close(jobs)
 
-	// Wait for all workers to complete
-	wg.Wait()
-	close(results)
-
-	// Collect results
+	// Collect results; the channel closes once every worker has finished
 	fmt.Println("Results:")
 	for result := range results {
 		fmt.Printf("Result: %d\n", result)
 	}
 }
 
-func workerPool(id int, jobs <-chan int, results chan<- int, wg *sync.WaitGroup) {
-	defer wg.Done()
-	for job := range jobs {
-		fmt.Printf("Worker %d processing job %d\n", id, job)
-		time.Sleep(100 * time.Millisecond)
-		results <- job * 2
+// WorkerPool starts numWorkers goroutines that apply process to each job and
+// send the outputs on the returned channel, in completion order. The channel
+// is closed once jobs is closed and drained, or once ctx is cancelled; after
+// cancellation workers stop picking up jobs and drop any unsent result.
+func WorkerPool(ctx context.Context, numWorkers int, jobs <-chan int, process func(int) int) <-chan int {
+	results := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < max(numWorkers, 1); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case job, ok := <-jobs:
+					if !ok {
+						return
+					}
+					select {
+					case results <- process(job):
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}()
 	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	return results
 }
 
 // Fan-out, fan-in pattern
@@ -167,6 +196,66 @@ func filterOdd(in <-chan int) <-chan int {
 	return out
 }
 
+// Result carries either a value or the error that stopped it through a pipeline.
+type Result[T any] struct {
+	Value T
+	Err   error
+}
+
+// StageFunc is a pipeline step that transforms a value and may fail.
+type StageFunc[In, Out any] func(In) (Out, error)
+
+// generateResults is the Result-carrying form of generate.
+func generateResults[T any](values ...T) <-chan Result[T] {
+	out := make(chan Result[T])
+	go func() {
+		defer close(out)
+		for _, v := range values {
+			out <- Result[T]{Value: v}
+		}
+	}()
+	return out
+}
+
+// Stage applies fn to each successful value from in. A value fn fails on
+// becomes an error Result, and error Results pass through untouched, so later
+// stages skip them and the consumer sees the original error.
+func Stage[In, Out any](in <-chan Result[In], fn StageFunc[In, Out]) <-chan Result[Out] {
+	out := make(chan Result[Out])
+	go func() {
+		defer close(out)
+		for r := range in {
+			if r.Err != nil {
+				out <- Result[Out]{Err: r.Err}
+				continue
+			}
+			value, err := fn(r.Value)
+			out <- Result[Out]{Value: value, Err: err}
+		}
+	}()
+	return out
+}
+
+// errorPipelineExample shows failures flowing to the consumer alongside values.
+func errorPipelineExample() {
+	fmt.Println("\n=== Pipeline With Errors ===")
+
+	parsed := Stage(generateResults("1", "2", "x", "4"), func(s string) (int, error) {
+		return strconv.Atoi(s)
+	})
+	squared := Stage(parsed, func(n int) (int, error) {
+		return n * n, nil
+	})
+
+	for result := range squared {
+		if result.Err != nil {
+			fmt.Printf("Pipeline error: %v\n", result.Err)
+			continue
+		}
+		fmt.Printf("Pipeline result: %d\n", result.Value)
+	}
+}
+
 // Broadcast pattern
 func broadcastExample() {
 	fmt.Println("\n=== Broadcast Pattern ===")
@@ -265,6 +354,7 @@ func patternsExample() {
 	workerPoolExample()
 	fanOutFanInExample()
 	pipelineExample()
+	errorPipelineExample()
 	broadcastExample()
 	cancellationExample()
 	rateLimitingExample()
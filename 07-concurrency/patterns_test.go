@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWorkerPool(t *testing.T) {
+	jobs := make(chan int)
+	results := WorkerPool(context.Background(), 4, jobs, func(n int) int { return n * 2 })
+
+	go func() {
+		defer close(jobs)
+		for i := 1; i <= 20; i++ {
+			jobs <- i
+		}
+	}()
+
+	var got []int
+	for result := range results {
+		got = append(got, result)
+	}
+	sort.Ints(got)
+
+	if len(got) != 20 {
+		t.Fatalf("got %d results; want 20", len(got))
+	}
+	for i, result := range got {
+		if result != (i+1)*2 {
+			t.Errorf("sorted result %d = %d; want %d", i, result, (i+1)*2)
+		}
+	}
+}
+
+func TestWorkerPoolCancellation(t *testing.T) {
+	const totalJobs = 1000
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Jobs are never closed: only cancellation can stop the workers
+	jobs := make(chan int, totalJobs)
+	for i := 0; i < totalJobs; i++ {
+		jobs <- i
+	}
+
+	var processed atomic.Int32
+	results := WorkerPool(ctx, 4, jobs, func(n int) int {
+		processed.Add(1)
+		time.Sleep(time.Millisecond)
+		return n
+	})
+
+	// Read a few results, then cancel mid-run and stop reading for a moment
+	for i := 0; i < 10; i++ {
+		<-results
+	}
+	cancel()
+	time.Sleep(20 * time.Millisecond)
+
+	// The results channel must close promptly even though jobs remain queued
+	closed := make(chan struct{})
+	go func() {
+		for range results {
+		}
+		close(closed)
+	}()
+
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("results channel not closed after cancellation")
+	}
+
+	if got := processed.Load(); got >= totalJobs {
+		t.Errorf("processed %d jobs; want cancellation to stop work early", got)
+	}
+	if len(jobs) == 0 {
+		t.Error("all jobs were consumed; want workers to stop before draining the queue")
+	}
+}
+
+func TestStageErrorPropagation(t *testing.T) {
+	errUnlucky := errors.New("unlucky number")
+
+	var squareCalls atomic.Int32
+	checked := Stage(generateResults(1, 2, 13, 4, 5), func(n int) (int, error) {
+		if n == 13 {
+			return 0, errUnlucky
+		}
+		return n, nil
+	})
+	squared := Stage(checked, func(n int) (int, error) {
+		squareCalls.Add(1)
+		return n * n, nil
+	})
+	labelled := Stage(squared, func(n int) (string, error) {
+		return "#" + strconv.Itoa(n), nil
+	})
+
+	var values []string
+	var errs []error
+	for result := range labelled {
+		if result.Err != nil {
+			errs = append(errs, result.Err)
+			continue
+		}
+		values = append(values, result.Value)
+	}
+
+	// Stages preserve order, so the error arrives between the surrounding values
+	if want := []string{"#1", "#4", "#16", "#25"}; !reflect.DeepEqual(values, want) {
+		t.Errorf("values = %v; want %v", values, want)
+	}
+	if len(errs) != 1 || !errors.Is(errs[0], errUnlucky) {
+		t.Errorf("errors = %v; want [%v]", errs, errUnlucky)
+	}
+
+	// The failed value short-circuits: downstream stages never see it
+	if got := squareCalls.Load(); got != 4 {
+		t.Errorf("square stage ran %d times; want 4", got)
+	}
+}
@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrAccountLocked is returned by Authenticate while a username is locked out
+// after too many failed login attempts.
+var ErrAccountLocked = errors.New("account locked")
+
+// loginFailures tracks consecutive failed logins for a single username.
+type loginFailures struct {
+	count       int
+	lastFailure time.Time
+	lockedUntil time.Time
+}
+
+// isLocked reports whether username is currently locked out. Authenticate
+// uses it to reject locked users before doing any password hashing; the
+// authoritative check happens in recordAttempt.
+func (s *Service) isLocked(username string) bool {
+	if s.maxAttempts <= 0 {
+		return false
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	failures, exists := s.failures[username]
+	return exists && s.now().Before(failures.lockedUntil)
+}
+
+// recordAttempt applies the outcome of a password check for an existing
+// username. It returns ErrAccountLocked if the username is locked, otherwise
+// a success clears the failure count and a failure increments it, locking
+// the username once maxAttempts consecutive failures have been seen.
+// The check and the update happen under one lock, so concurrent attempts
+// cannot slip past the threshold. It does nothing when lockout is disabled.
+func (s *Service) recordAttempt(username string, success bool) error {
+	if s.maxAttempts <= 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	failures := s.failures[username]
+	if now.Before(failures.lockedUntil) {
+		return ErrAccountLocked
+	}
+
+	if success {
+		delete(s.failures, username)
+		return nil
+	}
+
+	failures.count++
+	failures.lastFailure = now
+	if failures.count >= s.maxAttempts {
+		// Start a fresh count once the lockout expires
+		failures.count = 0
+		failures.lockedUntil = now.Add(s.lockoutWindow)
+	}
+	s.failures[username] = failures
+	return nil
+}
+
+// pruneFailures forgets usernames whose lockout has expired and whose last
+// failure is older than the lockout window.
+// The caller must hold s.mu for writing.
+func (s *Service) pruneFailures(now time.Time) {
+	for username, failures := range s.failures {
+		if !now.Before(failures.lockedUntil) && now.Sub(failures.lastFailure) > s.lockoutWindow {
+			delete(s.failures, username)
+		}
+	}
+}
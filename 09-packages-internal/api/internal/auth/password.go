@@ -0,0 +1,48 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// saltSize is the number of random bytes mixed into each password hash.
+const saltSize = 16
+
+// HashPassword returns a salted SHA-256 hash of password encoded as
+// "<salt>:<hash>" in hex. A fresh salt makes equal passwords hash differently.
+// UserStore implementations use it to produce the hashes Lookup returns.
+// A production system should prefer a deliberately slow hash such as bcrypt.
+func HashPassword(password string) (string, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+	return encodeHash(salt, password), nil
+}
+
+// verifyPassword reports whether password matches an encoded hash from
+// HashPassword. The comparison runs in constant time.
+func verifyPassword(encoded, password string) bool {
+	saltHex, _, found := strings.Cut(encoded, ":")
+	if !found {
+		return false
+	}
+
+	salt, err := hex.DecodeString(saltHex)
+	if err != nil {
+		return false
+	}
+
+	expected := encodeHash(salt, password)
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(encoded)) == 1
+}
+
+// encodeHash hashes salt+password and encodes both as "<salt>:<hash>".
+func encodeHash(salt []byte, password string) string {
+	sum := sha256.Sum256(append(append([]byte{}, salt...), password...))
+	return hex.EncodeToString(salt) + ":" + hex.EncodeToString(sum[:])
+}
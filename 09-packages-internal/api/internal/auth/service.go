@@ -1,18 +1,32 @@
 package auth
 
 import (
+	"context"
 	"crypto/rand"
-	"encoding/hex"
+	"errors"
 	"fmt"
+	"sort"
+	"sync"
 	"time"
 )
 
 // Service provides authentication functionality.
 // This is internal to the api package and cannot be imported by external packages.
 type Service struct {
-	secretKey []byte
-	tokenTTL  time.Duration
-	tokens    map[string]tokenInfo // In-memory token storage for demo
+	mu sync.RWMutex // Guards tokens, revoked, issued and failures
+
+	secretKey     []byte
+	tokenTTL      time.Duration
+	tokens        map[string]tokenInfo     // Tokens issued by this service, for sessions, roles and sliding
+	revoked       map[string]time.Time     // Revoked tokens mapped to their signed expiry
+	maxSessions   int                      // Maximum tokens per user, 0 means unlimited
+	issued        uint64                   // Number of tokens issued, used to order sessions
+	now           func() time.Time         // Clock, replaceable in tests
+	store         UserStore                // Source of users for Authenticate
+	maxAttempts   int                      // Failed logins before lockout, 0 disables lockout
+	lockoutWindow time.Duration            // How long a username stays locked out
+	failures      map[string]loginFailures // Failed login tracking by username
+	sliding       bool                     // Extend a token's expiry each time it validates
 }
 
 // tokenInfo holds information about a generated token.
@@ -20,14 +34,51 @@ type tokenInfo struct {
 	UserID    int
 	CreatedAt time.Time
 	ExpiresAt time.Time
+	Roles     []string
+	seq       uint64        // Issue order, breaks ties between equal CreatedAt values
+	ttl       time.Duration // Lifetime granted on issue and on each sliding renewal
 }
 
-// NewService creates a new authentication service.
+// NewService creates a new authentication service backed by an in-memory
+// user store seeded with the demo accounts.
 func NewService() *Service {
+	store := newMemoryStore()
+	seedDemoUsers(store)
+	return NewServiceWithStore(store)
+}
+
+// NewServiceWithStore creates a new authentication service that looks up
+// users in store.
+func NewServiceWithStore(store UserStore) *Service {
 	return &Service{
 		secretKey: []byte("demo-secret-key"),
 		tokenTTL:  time.Hour,
 		tokens:    make(map[string]tokenInfo),
+		revoked:   make(map[string]time.Time),
+		now:       time.Now,
+		store:     store,
+		failures:  make(map[string]loginFailures),
+	}
+}
+
+// seedDemoUsers registers the demo accounts used throughout the examples.
+func seedDemoUsers(store *memoryStore) {
+	demoUsers := []struct {
+		username string
+		userID   int
+		password string
+	}{
+		{"alice", 1, "password123"},
+		{"bob", 2, "secret456"},
+		{"admin", 100, "admin789"},
+	}
+
+	for _, user := range demoUsers {
+		hash, err := HashPassword(user.password)
+		if err != nil {
+			panic(fmt.Sprintf("seeding demo user %q: %v", user.username, err))
+		}
+		store.add(user.username, user.userID, hash)
 	}
 }
 
@@ -38,82 +89,393 @@ func NewServiceWithTTL(ttl time.Duration) *Service {
 	return service
 }
 
+// NewServiceWithMaxSessions creates a new authentication service that keeps at
+// most maxSessions tokens per user. Generating a token beyond the limit evicts
+// that user's oldest session, logging them out elsewhere.
+func NewServiceWithMaxSessions(maxSessions int) *Service {
+	service := NewService()
+	service.maxSessions = maxSessions
+	return service
+}
+
+// NewServiceWithSlidingExpiration creates a new authentication service whose
+// tokens expire ttl after their last successful validation rather than ttl
+// after issue, so active sessions stay alive while idle ones still expire.
+func NewServiceWithSlidingExpiration(ttl time.Duration) *Service {
+	service := NewServiceWithTTL(ttl)
+	service.sliding = true
+	return service
+}
+
+// NewServiceWithLockout creates a new authentication service that locks a
+// username for window after maxAttempts consecutive failed logins.
+func NewServiceWithLockout(maxAttempts int, window time.Duration) *Service {
+	service := NewService()
+	service.maxAttempts = maxAttempts
+	service.lockoutWindow = window
+	return service
+}
+
+// RegisterUser stores a new user with a salted hash of their password.
+// It returns an error if the username is empty or already taken, or if the
+// service's store does not support registration.
+func (s *Service) RegisterUser(username, password string) error {
+	if username == "" {
+		return fmt.Errorf("username cannot be empty")
+	}
+	if password == "" {
+		return fmt.Errorf("password cannot be empty")
+	}
+
+	registrar, ok := s.store.(userRegistrar)
+	if !ok {
+		return fmt.Errorf("user store does not support registration")
+	}
+
+	hash, err := HashPassword(password)
+	if err != nil {
+		return fmt.Errorf("failed to register user %q: %w", username, err)
+	}
+
+	_, err = registrar.Register(username, hash)
+	return err
+}
+
 // Authenticate validates user credentials and returns a user ID.
-// In a real implementation, this would check against a database.
+// The password is checked against the stored salted hash in constant time.
+// When lockout is enabled, a locked username is rejected with ErrAccountLocked
+// even if the password is correct. Only failures for existing users count
+// towards a lockout; unknown usernames and store errors do not.
 func (s *Service) Authenticate(username, password string) (int, error) {
-	// Demo authentication logic
-	validUsers := map[string]struct {
-		userID   int
-		password string
-	}{
-		"alice": {userID: 1, password: "password123"},
-		"bob":   {userID: 2, password: "secret456"},
-		"admin": {userID: 100, password: "admin789"},
+	// Cheap early rejection; recordAttempt re-checks under the same lock it counts with
+	if s.isLocked(username) {
+		return 0, fmt.Errorf("user %q: %w", username, ErrAccountLocked)
 	}
 
-	user, exists := validUsers[username]
-	if !exists {
-		return 0, fmt.Errorf("user %q not found", username)
+	userID, passwordHash, err := s.store.Lookup(username)
+	if err != nil {
+		return 0, fmt.Errorf("user %q: %w", username, err)
 	}
 
-	if user.password != password {
+	valid := verifyPassword(passwordHash, password)
+	if err := s.recordAttempt(username, valid); err != nil {
+		return 0, fmt.Errorf("user %q: %w", username, err)
+	}
+	if !valid {
 		return 0, fmt.Errorf("invalid password for user %q", username)
 	}
 
-	return user.userID, nil
+	return userID, nil
 }
 
-// GenerateToken creates a new authentication token for the given user ID.
+// GenerateToken creates a new signed authentication token for the given user ID.
+// The token carries the user ID and expiry, so it can be verified without
+// server state; revoked tokens are remembered until they expire.
 func (s *Service) GenerateToken(userID int) (string, error) {
-	// Generate a random token
-	tokenBytes := make([]byte, 16)
-	if _, err := rand.Read(tokenBytes); err != nil {
-		return "", fmt.Errorf("failed to generate token: %w", err)
+	return s.GenerateTokenWithTTL(userID, s.tokenTTL)
+}
+
+// GenerateTokenWithTTL creates a token that expires after ttl instead of the
+// service's default TTL, so short-lived tokens such as password-reset links
+// can coexist with normal sessions.
+func (s *Service) GenerateTokenWithTTL(userID int, ttl time.Duration) (string, error) {
+	if ttl <= 0 {
+		return "", fmt.Errorf("token TTL must be positive, got %v", ttl)
 	}
+	return s.generateToken(userID, ttl, nil)
+}
+
+// GenerateTokenWithClaims creates a token that carries the given roles, so
+// handlers can check permissions with ValidateTokenClaims.
+func (s *Service) GenerateTokenWithClaims(userID int, roles []string) (string, error) {
+	return s.generateToken(userID, s.tokenTTL, roles)
+}
 
-	token := hex.EncodeToString(tokenBytes)
+// generateToken issues a token with the given lifetime and roles.
+func (s *Service) generateToken(userID int, ttl time.Duration, roles []string) (string, error) {
+	nonce, err := newNonce()
+	if err != nil {
+		return "", err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.issueToken(userID, ttl, roles, nonce), nil
+}
+
+// RefreshToken exchanges a valid token for a new one with a fresh TTL.
+// The new token keeps the old token's lifetime and roles, so a short-lived
+// token cannot be refreshed into a full session. A token this service has no
+// record of keeps its signed expiry, since its lifetime is unknown. The old
+// token is revoked in the same critical section, so it can never be used
+// again once the new token exists.
+func (s *Service) RefreshToken(oldToken string) (string, error) {
+	nonce, err := newNonce()
+	if err != nil {
+		return "", err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	userID, expiresAt, err := s.validateToken(oldToken)
+	if err != nil {
+		return "", fmt.Errorf("cannot refresh token: %w", err)
+	}
+
+	info, tracked := s.tokens[oldToken]
+	if !tracked {
+		info.ttl = expiresAt.Sub(s.now())
+	}
+
+	// Revoke first so the session limit never evicts another session
+	s.revoke(oldToken)
+	return s.issueToken(userID, info.ttl, info.Roles, nonce), nil
+}
+
+// newNonce returns random bytes that keep every token unique.
+func newNonce() ([]byte, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+	return nonce, nil
+}
+
+// issueToken signs and records a new token for userID that expires after ttl.
+// The caller must hold s.mu for writing.
+func (s *Service) issueToken(userID int, ttl time.Duration, roles []string, nonce []byte) string {
+	now := s.now()
+	expiresAt := now.Add(ttl)
+	token := s.signToken(userID, expiresAt, nonce)
 
 	// Store token information
+	s.issued++
 	s.tokens[token] = tokenInfo{
 		UserID:    userID,
-		CreatedAt: time.Now(),
-		ExpiresAt: time.Now().Add(s.tokenTTL),
+		CreatedAt: now,
+		ExpiresAt: expiresAt,
+		Roles:     append([]string(nil), roles...),
+		seq:       s.issued,
+		ttl:       ttl,
 	}
 
-	return token, nil
+	s.evictExcessSessions(userID)
+
+	return token
+}
+
+// evictExcessSessions revokes the user's oldest tokens until at most
+// maxSessions remain. It does nothing when sessions are unlimited.
+// The caller must hold s.mu for writing.
+func (s *Service) evictExcessSessions(userID int) {
+	if s.maxSessions <= 0 {
+		return
+	}
+
+	sessions := s.userTokens(userID)
+	for len(sessions) > s.maxSessions {
+		s.revoke(sessions[0])
+		sessions = sessions[1:]
+	}
+}
+
+// userTokens returns the user's tokens ordered from oldest to newest.
+// The caller must hold s.mu.
+func (s *Service) userTokens(userID int) []string {
+	var tokens []string
+	for token, info := range s.tokens {
+		if info.UserID == userID {
+			tokens = append(tokens, token)
+		}
+	}
+
+	sort.Slice(tokens, func(i, j int) bool {
+		a, b := s.tokens[tokens[i]], s.tokens[tokens[j]]
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		return a.seq < b.seq
+	})
+	return tokens
 }
 
 // ValidateToken validates a token and returns the associated user ID.
+// The signature and expiry are checked from the token itself, so any token
+// signed with the service's key is accepted until it expires or is revoked.
 func (s *Service) ValidateToken(token string) (int, error) {
-	info, exists := s.tokens[token]
-	if !exists {
-		return 0, fmt.Errorf("invalid token")
+	userID, _, err := s.ValidateTokenWithInfo(token)
+	return userID, err
+}
+
+// ValidateTokenWithInfo validates a token like ValidateToken and also
+// returns when it expires, so clients can show the remaining session time.
+func (s *Service) ValidateTokenWithInfo(token string) (userID int, expiresAt time.Time, err error) {
+	userID, expiresAt, _, err = s.validate(token)
+	return userID, expiresAt, err
+}
+
+// ValidateTokenClaims validates a token like ValidateToken and also returns
+// the roles it was issued with. Tokens without claims return an empty slice.
+func (s *Service) ValidateTokenClaims(token string) (userID int, roles []string, err error) {
+	userID, _, info, err := s.validate(token)
+	if err != nil {
+		return 0, nil, err
 	}
 
-	if time.Now().After(info.ExpiresAt) {
+	roles = make([]string, len(info.Roles))
+	copy(roles, info.Roles)
+	return userID, roles, nil
+}
+
+// validate checks a token under the read lock and only takes the write lock
+// when an issued token's record must change: deleting it once expired, or
+// renewing it under sliding expiration. It returns a copy of that record,
+// which is zero for tokens the service has no record of.
+func (s *Service) validate(token string) (int, time.Time, tokenInfo, error) {
+	s.mu.RLock()
+	userID, expiresAt, err := s.checkToken(token)
+	info, tracked := s.tokens[token]
+	s.mu.RUnlock()
+
+	if !tracked || (!s.sliding && !errors.Is(err, ErrTokenExpired)) {
+		return userID, expiresAt, info, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	userID, expiresAt, err = s.validateToken(token)
+	return userID, expiresAt, s.tokens[token], err
+}
+
+// checkToken checks a token's signature, expiry and revocation status
+// without changing any state. With sliding expiration the stored expiry of
+// an issued token is authoritative.
+// The caller must hold s.mu.
+func (s *Service) checkToken(token string) (int, time.Time, error) {
+	userID, expiresAt, err := s.parseToken(token)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	if info, tracked := s.tokens[token]; s.sliding && tracked {
+		// The signed expiry only covers the first window
+		expiresAt = info.ExpiresAt
+	}
+
+	if s.now().After(expiresAt) {
+		return 0, time.Time{}, ErrTokenExpired
+	}
+
+	if _, revoked := s.revoked[token]; revoked {
+		return 0, time.Time{}, ErrTokenRevoked
+	}
+
+	return userID, expiresAt, nil
+}
+
+// validateToken checks a token like checkToken, deletes the record of an
+// expired token and, with sliding expiration, pushes the expiry forward on
+// success.
+// The caller must hold s.mu for writing.
+func (s *Service) validateToken(token string) (int, time.Time, error) {
+	userID, expiresAt, err := s.checkToken(token)
+	if errors.Is(err, ErrTokenExpired) {
 		// Clean up expired token
 		delete(s.tokens, token)
-		return 0, fmt.Errorf("token expired")
+	}
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	if info, tracked := s.tokens[token]; s.sliding && tracked {
+		info.ExpiresAt = s.now().Add(info.ttl)
+		s.tokens[token] = info
+		expiresAt = info.ExpiresAt
 	}
 
-	return info.UserID, nil
+	return userID, expiresAt, nil
 }
 
-// RevokeToken revokes (deletes) a token.
+// revoke deletes a token's record and denylists it until its signed expiry,
+// after which it is rejected as expired anyway.
+// The caller must hold s.mu for writing.
+func (s *Service) revoke(token string) {
+	delete(s.tokens, token)
+
+	if _, expiresAt, err := s.parseToken(token); err == nil && !s.now().After(expiresAt) {
+		s.revoked[token] = expiresAt
+	}
+}
+
+// RevokeToken revokes a token so it is rejected until it expires. It returns
+// an error if the token is invalid, expired or already revoked, unless the
+// service still holds a record of it.
 func (s *Service) RevokeToken(token string) error {
-	if _, exists := s.tokens[token]; !exists {
-		return fmt.Errorf("token not found")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, tracked := s.tokens[token]; !tracked {
+		if _, _, err := s.checkToken(token); err != nil {
+			return fmt.Errorf("token not found")
+		}
 	}
 
-	delete(s.tokens, token)
+	s.revoke(token)
 	return nil
 }
 
-// CleanupExpiredTokens removes all expired tokens from memory.
+// TokensForUser returns the user's unexpired tokens ordered from oldest to newest.
+func (s *Service) TokensForUser(userID int) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := s.now()
+	var active []string
+	for _, token := range s.userTokens(userID) {
+		if !now.After(s.tokens[token].ExpiresAt) {
+			active = append(active, token)
+		}
+	}
+	return active
+}
+
+// RevokeAllForUser revokes every token issued to the user, logging them out
+// everywhere, and returns the number of tokens revoked.
+func (s *Service) RevokeAllForUser(userID int) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	revoked := 0
+	for token, info := range s.tokens {
+		if info.UserID == userID {
+			s.revoke(token)
+			revoked++
+		}
+	}
+	return revoked
+}
+
+// CleanupExpiredTokens removes all expired tokens from memory and returns
+// how many were removed. It also forgets revocations of tokens that have
+// since expired and stale failed-login records.
 func (s *Service) CleanupExpiredTokens() int {
-	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
 	cleaned := 0
 
+	s.pruneFailures(now)
+
+	for token, expiresAt := range s.revoked {
+		if now.After(expiresAt) {
+			delete(s.revoked, token)
+		}
+	}
+
 	for token, info := range s.tokens {
 		if now.After(info.ExpiresAt) {
 			delete(s.tokens, token)
@@ -124,8 +486,39 @@ func (s *Service) CleanupExpiredTokens() int {
 	return cleaned
 }
 
+// StartCleanup launches a goroutine that calls CleanupExpiredTokens every
+// interval until ctx is cancelled. It returns immediately, or with an error
+// and without starting anything if interval is not positive.
+func (s *Service) StartCleanup(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("cleanup interval must be positive, got %v", interval)
+	}
+
+	go s.runCleanup(ctx, interval)
+	return nil
+}
+
+// runCleanup removes expired tokens on every tick and stops the ticker
+// when ctx is cancelled.
+func (s *Service) runCleanup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.CleanupExpiredTokens()
+		}
+	}
+}
+
 // GetTokenCount returns the number of active tokens.
 func (s *Service) GetTokenCount() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	return len(s.tokens)
 }
 
@@ -137,5 +530,5 @@ func (s *Service) isValidSecret() bool {
 
 // String returns a string representation of the service (without sensitive data).
 func (s *Service) String() string {
-	return fmt.Sprintf("AuthService{TokenTTL: %v, ActiveTokens: %d}", s.tokenTTL, len(s.tokens))
+	return fmt.Sprintf("AuthService{TokenTTL: %v, ActiveTokens: %d}", s.tokenTTL, s.GetTokenCount())
 }
@@ -0,0 +1,956 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a manually advanced clock for deterministic expiry tests.
+type fakeClock struct {
+	current time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{current: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.current
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.current = c.current.Add(d)
+}
+
+func TestMaxSessionsEvictsOldest(t *testing.T) {
+	clock := newFakeClock()
+	service := NewServiceWithMaxSessions(2)
+	service.now = clock.Now
+
+	var tokens []string
+	for i := 0; i < 3; i++ {
+		token, err := service.GenerateToken(1)
+		if err != nil {
+			t.Fatalf("GenerateToken(1) unexpected error: %v", err)
+		}
+		tokens = append(tokens, token)
+		clock.Advance(time.Minute)
+	}
+
+	other, err := service.GenerateToken(2)
+	if err != nil {
+		t.Fatalf("GenerateToken(2) unexpected error: %v", err)
+	}
+
+	if _, err := service.ValidateToken(tokens[0]); err == nil {
+		t.Error("oldest session should have been evicted")
+	}
+	for _, token := range append(tokens[1:], other) {
+		if _, err := service.ValidateToken(token); err != nil {
+			t.Errorf("ValidateToken(%s) unexpected error: %v", token, err)
+		}
+	}
+	if count := service.GetTokenCount(); count != 3 {
+		t.Errorf("GetTokenCount() = %d; want 3", count)
+	}
+}
+
+func TestMaxSessionsUnlimitedByDefault(t *testing.T) {
+	service := NewService()
+
+	for i := 0; i < 5; i++ {
+		if _, err := service.GenerateToken(1); err != nil {
+			t.Fatalf("GenerateToken(1) unexpected error: %v", err)
+		}
+	}
+
+	if count := service.GetTokenCount(); count != 5 {
+		t.Errorf("GetTokenCount() = %d; want 5", count)
+	}
+}
+
+func TestServiceConcurrentAccess(t *testing.T) {
+	service := NewServiceWithMaxSessions(5)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(userID int) {
+			defer wg.Done()
+
+			token, err := service.GenerateToken(userID % 5)
+			if err != nil {
+				t.Errorf("GenerateToken(%d) unexpected error: %v", userID%5, err)
+				return
+			}
+
+			// The token may already have been evicted by a newer session
+			// for the same user, but validation must never race
+			_, _ = service.ValidateToken(token)
+			_ = service.GetTokenCount()
+			_ = service.String()
+			service.CleanupExpiredTokens()
+			_ = service.RevokeToken(token)
+		}(i)
+	}
+	wg.Wait()
+
+	if count := service.GetTokenCount(); count != 0 {
+		t.Errorf("GetTokenCount() after revoking every token = %d; want 0", count)
+	}
+}
+
+func TestSignedTokenRoundTrip(t *testing.T) {
+	service := NewService()
+
+	token, err := service.GenerateToken(42)
+	if err != nil {
+		t.Fatalf("GenerateToken(42) unexpected error: %v", err)
+	}
+
+	userID, err := service.ValidateToken(token)
+	if err != nil || userID != 42 {
+		t.Errorf("ValidateToken() = (%d, %v); want (42, nil)", userID, err)
+	}
+}
+
+func TestSignedTokenTampered(t *testing.T) {
+	service := NewService()
+
+	token, err := service.GenerateToken(1)
+	if err != nil {
+		t.Fatalf("GenerateToken(1) unexpected error: %v", err)
+	}
+
+	// Swap the user ID in the payload but keep the original signature
+	encoded, signature, _ := strings.Cut(token, ".")
+	payload, err := tokenEncoding.DecodeString(encoded)
+	if err != nil {
+		t.Fatalf("decoding payload: %v", err)
+	}
+	forged := tokenEncoding.EncodeToString([]byte("100"+string(payload)[1:])) + "." + signature
+
+	// A token signed with a different key must also be rejected
+	otherService := NewService()
+	otherService.secretKey = []byte("another-secret")
+	foreign, err := otherService.GenerateToken(1)
+	if err != nil {
+		t.Fatalf("GenerateToken(1) unexpected error: %v", err)
+	}
+
+	for _, bad := range []string{forged, foreign, "not-a-token", token + "x", ""} {
+		if _, err := service.ValidateToken(bad); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("ValidateToken(%q) error = %v; want ErrInvalidToken", bad, err)
+		}
+	}
+}
+
+func TestSignedTokenExpired(t *testing.T) {
+	clock := newFakeClock()
+	service := NewServiceWithTTL(time.Minute)
+	service.now = clock.Now
+
+	token, err := service.GenerateToken(1)
+	if err != nil {
+		t.Fatalf("GenerateToken(1) unexpected error: %v", err)
+	}
+
+	clock.Advance(time.Minute + time.Second)
+
+	if _, err := service.ValidateToken(token); !errors.Is(err, ErrTokenExpired) {
+		t.Errorf("ValidateToken() after TTL error = %v; want ErrTokenExpired", err)
+	}
+	if count := service.GetTokenCount(); count != 0 {
+		t.Errorf("GetTokenCount() after expiry = %d; want 0", count)
+	}
+}
+
+func TestSignedTokenRevoked(t *testing.T) {
+	service := NewService()
+
+	token, err := service.GenerateToken(1)
+	if err != nil {
+		t.Fatalf("GenerateToken(1) unexpected error: %v", err)
+	}
+	if err := service.RevokeToken(token); err != nil {
+		t.Fatalf("RevokeToken() unexpected error: %v", err)
+	}
+
+	if _, err := service.ValidateToken(token); !errors.Is(err, ErrTokenRevoked) {
+		t.Errorf("ValidateToken() after revoke error = %v; want ErrTokenRevoked", err)
+	}
+}
+
+func TestValidateUntrackedToken(t *testing.T) {
+	clock := newFakeClock()
+	issuer := NewServiceWithTTL(time.Hour)
+	issuer.now = clock.Now
+
+	token, err := issuer.GenerateToken(5)
+	if err != nil {
+		t.Fatalf("GenerateToken(5) unexpected error: %v", err)
+	}
+
+	// Another instance sharing the key has no record of the token
+	service := NewServiceWithTTL(time.Hour)
+	service.now = clock.Now
+
+	if userID, err := service.ValidateToken(token); err != nil || userID != 5 {
+		t.Errorf("ValidateToken(untracked) = (%d, %v); want (5, nil)", userID, err)
+	}
+	if _, roles, err := service.ValidateTokenClaims(token); err != nil || len(roles) != 0 {
+		t.Errorf("ValidateTokenClaims(untracked) = (%v, %v); want no roles", roles, err)
+	}
+
+	clock.Advance(10 * time.Minute)
+	refreshed, err := service.RefreshToken(token)
+	if err != nil {
+		t.Fatalf("RefreshToken(untracked) unexpected error: %v", err)
+	}
+	if _, err := service.ValidateToken(token); !errors.Is(err, ErrTokenRevoked) {
+		t.Errorf("ValidateToken(untracked) after refresh error = %v; want ErrTokenRevoked", err)
+	}
+
+	// The refreshed token cannot outlive the original one
+	_, expiresAt, err := service.ValidateTokenWithInfo(refreshed)
+	if err != nil {
+		t.Fatalf("ValidateTokenWithInfo(refreshed) unexpected error: %v", err)
+	}
+	if want := clock.Now().Add(50 * time.Minute); !expiresAt.Equal(want) {
+		t.Errorf("refreshed token expiresAt = %v; want %v", expiresAt, want)
+	}
+}
+
+func TestRevokeUntrackedToken(t *testing.T) {
+	service := NewService()
+
+	token, err := NewService().GenerateToken(5)
+	if err != nil {
+		t.Fatalf("GenerateToken(5) unexpected error: %v", err)
+	}
+
+	if err := service.RevokeToken(token); err != nil {
+		t.Fatalf("RevokeToken(untracked) unexpected error: %v", err)
+	}
+	if _, err := service.ValidateToken(token); !errors.Is(err, ErrTokenRevoked) {
+		t.Errorf("ValidateToken() after revoke error = %v; want ErrTokenRevoked", err)
+	}
+
+	for _, bad := range []string{token, "garbage"} {
+		if err := service.RevokeToken(bad); err == nil {
+			t.Errorf("RevokeToken(%q) expected error but got none", bad)
+		}
+	}
+}
+
+func TestCleanupPrunesExpiredRevocations(t *testing.T) {
+	clock := newFakeClock()
+	service := NewServiceWithTTL(time.Hour)
+	service.now = clock.Now
+
+	short, err := service.GenerateTokenWithTTL(1, time.Minute)
+	if err != nil {
+		t.Fatalf("GenerateTokenWithTTL() unexpected error: %v", err)
+	}
+	long, err := service.GenerateToken(1)
+	if err != nil {
+		t.Fatalf("GenerateToken() unexpected error: %v", err)
+	}
+	for _, token := range []string{short, long} {
+		if err := service.RevokeToken(token); err != nil {
+			t.Fatalf("RevokeToken() unexpected error: %v", err)
+		}
+	}
+
+	clock.Advance(2 * time.Minute)
+	service.CleanupExpiredTokens()
+
+	if _, ok := service.revoked[short]; ok {
+		t.Error("revocation of the expired token was not pruned")
+	}
+	if _, err := service.ValidateToken(short); !errors.Is(err, ErrTokenExpired) {
+		t.Errorf("ValidateToken(short) error = %v; want ErrTokenExpired", err)
+	}
+	if _, err := service.ValidateToken(long); !errors.Is(err, ErrTokenRevoked) {
+		t.Errorf("ValidateToken(long) error = %v; want ErrTokenRevoked", err)
+	}
+}
+
+func TestRegisterAndAuthenticate(t *testing.T) {
+	service := NewService()
+
+	if err := service.RegisterUser("carol", "Correct-Horse-7"); err != nil {
+		t.Fatalf("RegisterUser(%q) unexpected error: %v", "carol", err)
+	}
+
+	userID, err := service.Authenticate("carol", "Correct-Horse-7")
+	if err != nil {
+		t.Fatalf("Authenticate with correct password unexpected error: %v", err)
+	}
+	if userID <= 100 {
+		t.Errorf("registered user ID = %d; want an ID after the demo users", userID)
+	}
+
+	if _, err := service.Authenticate("carol", "correct-horse-7"); err == nil {
+		t.Error("Authenticate with wrong password expected error but got none")
+	}
+	if _, err := service.Authenticate("dave", "Correct-Horse-7"); err == nil {
+		t.Error("Authenticate with unknown user expected error but got none")
+	}
+
+	if err := service.RegisterUser("carol", "another"); err == nil {
+		t.Error("RegisterUser with duplicate username expected error but got none")
+	}
+	if err := service.RegisterUser("", "password"); err == nil {
+		t.Error("RegisterUser with empty username expected error but got none")
+	}
+}
+
+func TestDemoUsersAuthenticate(t *testing.T) {
+	service := NewService()
+
+	userID, err := service.Authenticate("alice", "password123")
+	if err != nil || userID != 1 {
+		t.Errorf("Authenticate(%q) = (%d, %v); want (1, nil)", "alice", userID, err)
+	}
+}
+
+func TestPasswordHashIsSalted(t *testing.T) {
+	first, err := HashPassword("same-password")
+	if err != nil {
+		t.Fatalf("HashPassword unexpected error: %v", err)
+	}
+	second, err := HashPassword("same-password")
+	if err != nil {
+		t.Fatalf("HashPassword unexpected error: %v", err)
+	}
+
+	if first == second {
+		t.Error("hashing the same password twice produced identical hashes; want distinct salts")
+	}
+	if strings.Contains(first, "same-password") {
+		t.Error("hash contains the plaintext password")
+	}
+	if !verifyPassword(first, "same-password") || !verifyPassword(second, "same-password") {
+		t.Error("verifyPassword rejected the correct password")
+	}
+}
+
+func TestHashPasswordFormat(t *testing.T) {
+	hash, err := HashPassword("s3cret")
+	if err != nil {
+		t.Fatalf("HashPassword unexpected error: %v", err)
+	}
+
+	saltHex, sumHex, found := strings.Cut(hash, ":")
+	if !found {
+		t.Fatalf("HashPassword() = %q; want \"<salt hex>:<sha256 hex>\"", hash)
+	}
+	salt, err := hex.DecodeString(saltHex)
+	if err != nil || len(salt) != saltSize {
+		t.Fatalf("HashPassword() salt = %q; want %d hex-encoded bytes", saltHex, saltSize)
+	}
+	sum := sha256.Sum256(append(salt, "s3cret"...))
+	if want := hex.EncodeToString(sum[:]); sumHex != want {
+		t.Errorf("HashPassword() sum = %q; want %q", sumHex, want)
+	}
+
+	// A store may build the same format itself
+	manual := saltHex + ":" + hex.EncodeToString(sum[:])
+	if !verifyPassword(manual, "s3cret") {
+		t.Errorf("verifyPassword(%q) rejected a hash in the documented format", manual)
+	}
+}
+
+func TestRefreshToken(t *testing.T) {
+	clock := newFakeClock()
+	service := NewServiceWithTTL(time.Hour)
+	service.now = clock.Now
+
+	oldToken, err := service.GenerateToken(7)
+	if err != nil {
+		t.Fatalf("GenerateToken(7) unexpected error: %v", err)
+	}
+
+	clock.Advance(30 * time.Minute)
+
+	newToken, err := service.RefreshToken(oldToken)
+	if err != nil {
+		t.Fatalf("RefreshToken() unexpected error: %v", err)
+	}
+	if newToken == oldToken {
+		t.Fatal("RefreshToken() returned the old token")
+	}
+
+	if _, err := service.ValidateToken(oldToken); !errors.Is(err, ErrTokenRevoked) {
+		t.Errorf("ValidateToken(old) error = %v; want ErrTokenRevoked", err)
+	}
+
+	// The new token has a fresh TTL, so it outlives the old token's expiry
+	clock.Advance(45 * time.Minute)
+	if userID, err := service.ValidateToken(newToken); err != nil || userID != 7 {
+		t.Errorf("ValidateToken(new) = (%d, %v); want (7, nil)", userID, err)
+	}
+
+	if _, err := service.RefreshToken(oldToken); err == nil {
+		t.Error("RefreshToken with a revoked token expected error but got none")
+	}
+	if _, err := service.RefreshToken("garbage"); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("RefreshToken(garbage) error = %v; want ErrInvalidToken", err)
+	}
+}
+
+func TestRefreshExpiredToken(t *testing.T) {
+	clock := newFakeClock()
+	service := NewServiceWithTTL(time.Minute)
+	service.now = clock.Now
+
+	token, err := service.GenerateToken(1)
+	if err != nil {
+		t.Fatalf("GenerateToken(1) unexpected error: %v", err)
+	}
+
+	clock.Advance(2 * time.Minute)
+
+	if _, err := service.RefreshToken(token); !errors.Is(err, ErrTokenExpired) {
+		t.Errorf("RefreshToken(expired) error = %v; want ErrTokenExpired", err)
+	}
+}
+
+func TestRefreshKeepsTokenTTL(t *testing.T) {
+	clock := newFakeClock()
+	service := NewServiceWithTTL(time.Hour)
+	service.now = clock.Now
+
+	resetToken, err := service.GenerateTokenWithTTL(1, time.Second)
+	if err != nil {
+		t.Fatalf("GenerateTokenWithTTL() unexpected error: %v", err)
+	}
+
+	clock.Advance(500 * time.Millisecond)
+
+	refreshed, err := service.RefreshToken(resetToken)
+	if err != nil {
+		t.Fatalf("RefreshToken() unexpected error: %v", err)
+	}
+
+	_, expiresAt, err := service.ValidateTokenWithInfo(refreshed)
+	if err != nil {
+		t.Fatalf("ValidateTokenWithInfo(refreshed) unexpected error: %v", err)
+	}
+	if want := clock.Now().Add(time.Second); !expiresAt.Equal(want) {
+		t.Errorf("refreshed token expiresAt = %v; want %v", expiresAt, want)
+	}
+
+	// The refreshed token expires on the short schedule, not the service default
+	clock.Advance(2 * time.Second)
+	if _, err := service.ValidateToken(refreshed); !errors.Is(err, ErrTokenExpired) {
+		t.Errorf("ValidateToken(refreshed) after 2s error = %v; want ErrTokenExpired", err)
+	}
+}
+
+func TestValidateTokenWithInfo(t *testing.T) {
+	clock := newFakeClock()
+	service := NewServiceWithTTL(90 * time.Minute)
+	service.now = clock.Now
+
+	token, err := service.GenerateToken(3)
+	if err != nil {
+		t.Fatalf("GenerateToken(3) unexpected error: %v", err)
+	}
+
+	want := clock.Now().Add(90 * time.Minute)
+
+	clock.Advance(10 * time.Minute)
+
+	userID, expiresAt, err := service.ValidateTokenWithInfo(token)
+	if err != nil {
+		t.Fatalf("ValidateTokenWithInfo() unexpected error: %v", err)
+	}
+	if userID != 3 {
+		t.Errorf("ValidateTokenWithInfo() userID = %d; want 3", userID)
+	}
+	if !expiresAt.Equal(want) {
+		t.Errorf("ValidateTokenWithInfo() expiresAt = %v; want %v", expiresAt, want)
+	}
+	if remaining := expiresAt.Sub(clock.Now()); remaining != 80*time.Minute {
+		t.Errorf("remaining session time = %v; want %v", remaining, 80*time.Minute)
+	}
+
+	if _, _, err := service.ValidateTokenWithInfo("garbage"); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("ValidateTokenWithInfo(garbage) error = %v; want ErrInvalidToken", err)
+	}
+}
+
+func TestLockoutAfterMaxAttempts(t *testing.T) {
+	clock := newFakeClock()
+	service := NewServiceWithLockout(3, 15*time.Minute)
+	service.now = clock.Now
+
+	for i := 1; i <= 3; i++ {
+		_, err := service.Authenticate("alice", "wrong")
+		if err == nil || errors.Is(err, ErrAccountLocked) {
+			t.Fatalf("attempt %d: Authenticate() error = %v; want invalid password", i, err)
+		}
+	}
+
+	// The correct password is rejected while locked
+	if _, err := service.Authenticate("alice", "password123"); !errors.Is(err, ErrAccountLocked) {
+		t.Errorf("Authenticate() while locked error = %v; want ErrAccountLocked", err)
+	}
+
+	// Other users are unaffected
+	if _, err := service.Authenticate("bob", "secret456"); err != nil {
+		t.Errorf("Authenticate(bob) unexpected error: %v", err)
+	}
+
+	clock.Advance(15*time.Minute + time.Second)
+
+	userID, err := service.Authenticate("alice", "password123")
+	if err != nil || userID != 1 {
+		t.Errorf("Authenticate() after lockout = (%d, %v); want (1, nil)", userID, err)
+	}
+}
+
+func TestLockoutResetOnSuccess(t *testing.T) {
+	clock := newFakeClock()
+	service := NewServiceWithLockout(3, 15*time.Minute)
+	service.now = clock.Now
+
+	for round := 0; round < 3; round++ {
+		for i := 0; i < 2; i++ {
+			service.Authenticate("alice", "wrong")
+		}
+		if _, err := service.Authenticate("alice", "password123"); err != nil {
+			t.Fatalf("round %d: Authenticate() unexpected error: %v", round, err)
+		}
+	}
+}
+
+func TestLockoutIgnoresUnknownUsers(t *testing.T) {
+	service := NewServiceWithLockout(3, 15*time.Minute)
+
+	for i := 0; i < 100; i++ {
+		if _, err := service.Authenticate(fmt.Sprintf("ghost%d", i), "wrong"); !errors.Is(err, ErrUserNotFound) {
+			t.Fatalf("Authenticate(ghost%d) error = %v; want ErrUserNotFound", i, err)
+		}
+	}
+
+	if got := len(service.failures); got != 0 {
+		t.Errorf("failure records = %d after unknown usernames; want 0", got)
+	}
+}
+
+func TestLockoutIgnoresStoreErrors(t *testing.T) {
+	service := NewServiceWithStore(&fakeUserStore{err: errors.New("database unavailable")})
+	service.maxAttempts = 1
+	service.lockoutWindow = time.Minute
+
+	for i := 0; i < 5; i++ {
+		service.Authenticate("erin", "s3cret")
+	}
+
+	if got := len(service.failures); got != 0 {
+		t.Errorf("failure records = %d after store errors; want 0", got)
+	}
+}
+
+func TestLockoutConcurrentAttempts(t *testing.T) {
+	const attempts = 50
+
+	clock := newFakeClock()
+	service := NewServiceWithLockout(3, 15*time.Minute)
+	service.now = clock.Now
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		rejected int // Attempts answered with "invalid password" rather than a lockout
+	)
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := service.Authenticate("alice", "wrong")
+			if err != nil && !errors.Is(err, ErrAccountLocked) {
+				mu.Lock()
+				rejected++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Exactly maxAttempts failures are counted before the lock takes effect
+	if rejected != 3 {
+		t.Errorf("password checks counted = %d; want 3", rejected)
+	}
+	if _, err := service.Authenticate("alice", "password123"); !errors.Is(err, ErrAccountLocked) {
+		t.Errorf("Authenticate() after concurrent failures error = %v; want ErrAccountLocked", err)
+	}
+}
+
+func TestCleanupPrunesStaleFailures(t *testing.T) {
+	clock := newFakeClock()
+	service := NewServiceWithLockout(3, 15*time.Minute)
+	service.now = clock.Now
+
+	service.Authenticate("bob", "wrong") // One failure, never locked
+	for i := 0; i < 3; i++ {
+		service.Authenticate("alice", "wrong") // Locked
+	}
+
+	clock.Advance(10 * time.Minute)
+	service.CleanupExpiredTokens()
+	if got := len(service.failures); got != 2 {
+		t.Errorf("failure records within window = %d; want 2", got)
+	}
+
+	clock.Advance(6 * time.Minute)
+	service.CleanupExpiredTokens()
+	if got := len(service.failures); got != 0 {
+		t.Errorf("failure records after window = %d; want 0", got)
+	}
+}
+
+func TestLockoutDisabledByDefault(t *testing.T) {
+	service := NewService()
+
+	for i := 0; i < 20; i++ {
+		service.Authenticate("alice", "wrong")
+	}
+
+	if _, err := service.Authenticate("alice", "password123"); err != nil {
+		t.Errorf("Authenticate() unexpected error: %v", err)
+	}
+}
+
+func TestGenerateTokenWithTTL(t *testing.T) {
+	clock := newFakeClock()
+	service := NewServiceWithTTL(time.Hour)
+	service.now = clock.Now
+
+	resetToken, err := service.GenerateTokenWithTTL(1, time.Second)
+	if err != nil {
+		t.Fatalf("GenerateTokenWithTTL(1, 1s) unexpected error: %v", err)
+	}
+	sessionToken, err := service.GenerateTokenWithTTL(1, time.Hour)
+	if err != nil {
+		t.Fatalf("GenerateTokenWithTTL(1, 1h) unexpected error: %v", err)
+	}
+
+	clock.Advance(2 * time.Second)
+
+	if _, err := service.ValidateToken(resetToken); !errors.Is(err, ErrTokenExpired) {
+		t.Errorf("ValidateToken(1s token) error = %v; want ErrTokenExpired", err)
+	}
+	if _, err := service.ValidateToken(sessionToken); err != nil {
+		t.Errorf("ValidateToken(1h token) unexpected error: %v", err)
+	}
+
+	if _, err := service.GenerateTokenWithTTL(1, 0); err == nil {
+		t.Error("GenerateTokenWithTTL(1, 0) expected error but got none")
+	}
+}
+
+func TestRevokeAllForUser(t *testing.T) {
+	service := NewService()
+
+	var userOneTokens []string
+	for i := 0; i < 3; i++ {
+		token, err := service.GenerateToken(1)
+		if err != nil {
+			t.Fatalf("GenerateToken(1) unexpected error: %v", err)
+		}
+		userOneTokens = append(userOneTokens, token)
+	}
+	userTwoToken, err := service.GenerateToken(2)
+	if err != nil {
+		t.Fatalf("GenerateToken(2) unexpected error: %v", err)
+	}
+
+	if got := service.TokensForUser(1); len(got) != 3 {
+		t.Fatalf("TokensForUser(1) returned %d tokens; want 3", len(got))
+	}
+
+	if revoked := service.RevokeAllForUser(1); revoked != 3 {
+		t.Errorf("RevokeAllForUser(1) = %d; want 3", revoked)
+	}
+
+	for _, token := range userOneTokens {
+		if _, err := service.ValidateToken(token); !errors.Is(err, ErrTokenRevoked) {
+			t.Errorf("ValidateToken(user 1 token) error = %v; want ErrTokenRevoked", err)
+		}
+	}
+	if _, err := service.ValidateToken(userTwoToken); err != nil {
+		t.Errorf("ValidateToken(user 2 token) unexpected error: %v", err)
+	}
+
+	if got := service.TokensForUser(1); len(got) != 0 {
+		t.Errorf("TokensForUser(1) after revoke = %v; want none", got)
+	}
+	if revoked := service.RevokeAllForUser(1); revoked != 0 {
+		t.Errorf("RevokeAllForUser(1) again = %d; want 0", revoked)
+	}
+}
+
+func TestTokensForUserSkipsExpired(t *testing.T) {
+	clock := newFakeClock()
+	service := NewServiceWithTTL(time.Hour)
+	service.now = clock.Now
+
+	if _, err := service.GenerateTokenWithTTL(1, time.Minute); err != nil {
+		t.Fatalf("GenerateTokenWithTTL() unexpected error: %v", err)
+	}
+	session, err := service.GenerateToken(1)
+	if err != nil {
+		t.Fatalf("GenerateToken() unexpected error: %v", err)
+	}
+
+	clock.Advance(2 * time.Minute)
+
+	got := service.TokensForUser(1)
+	if len(got) != 1 || got[0] != session {
+		t.Errorf("TokensForUser(1) = %v; want only the unexpired session", got)
+	}
+}
+
+func TestStartCleanupRemovesExpiredTokens(t *testing.T) {
+	service := NewService()
+
+	if _, err := service.GenerateTokenWithTTL(1, time.Millisecond); err != nil {
+		t.Fatalf("GenerateTokenWithTTL() unexpected error: %v", err)
+	}
+	if _, err := service.GenerateTokenWithTTL(2, time.Hour); err != nil {
+		t.Fatalf("GenerateTokenWithTTL() unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := service.StartCleanup(ctx, 5*time.Millisecond); err != nil {
+		t.Fatalf("StartCleanup() unexpected error: %v", err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for service.GetTokenCount() != 1 {
+		if time.Now().After(deadline) {
+			t.Fatalf("GetTokenCount() = %d after 1s; want 1", service.GetTokenCount())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestStartCleanupRejectsNonPositiveInterval(t *testing.T) {
+	service := NewService()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	for _, interval := range []time.Duration{0, -time.Second} {
+		if err := service.StartCleanup(ctx, interval); err == nil {
+			t.Errorf("StartCleanup(%v) expected error but got none", interval)
+		}
+	}
+}
+
+func TestRunCleanupStopsOnCancel(t *testing.T) {
+	service := NewService()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		service.runCleanup(ctx, time.Millisecond)
+		close(done)
+	}()
+
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("runCleanup did not return after the context was cancelled")
+	}
+}
+
+func TestSlidingExpiration(t *testing.T) {
+	clock := newFakeClock()
+	service := NewServiceWithSlidingExpiration(10 * time.Minute)
+	service.now = clock.Now
+
+	token, err := service.GenerateToken(1)
+	if err != nil {
+		t.Fatalf("GenerateToken(1) unexpected error: %v", err)
+	}
+
+	// Stay active well past the original expiry
+	var lastExpiry time.Time
+	for i := 0; i < 5; i++ {
+		clock.Advance(8 * time.Minute)
+
+		_, expiresAt, err := service.ValidateTokenWithInfo(token)
+		if err != nil {
+			t.Fatalf("validation %d: unexpected error: %v", i, err)
+		}
+		if want := clock.Now().Add(10 * time.Minute); !expiresAt.Equal(want) {
+			t.Errorf("validation %d: expiresAt = %v; want %v", i, expiresAt, want)
+		}
+		if !expiresAt.After(lastExpiry) {
+			t.Errorf("validation %d: expiry did not move forward from %v", i, lastExpiry)
+		}
+		lastExpiry = expiresAt
+	}
+
+	// An idle token still expires
+	clock.Advance(11 * time.Minute)
+	if _, err := service.ValidateToken(token); !errors.Is(err, ErrTokenExpired) {
+		t.Errorf("ValidateToken(idle token) error = %v; want ErrTokenExpired", err)
+	}
+}
+
+func TestFixedExpirationByDefault(t *testing.T) {
+	clock := newFakeClock()
+	service := NewServiceWithTTL(10 * time.Minute)
+	service.now = clock.Now
+
+	token, err := service.GenerateToken(1)
+	if err != nil {
+		t.Fatalf("GenerateToken(1) unexpected error: %v", err)
+	}
+
+	clock.Advance(8 * time.Minute)
+	if _, err := service.ValidateToken(token); err != nil {
+		t.Fatalf("ValidateToken() unexpected error: %v", err)
+	}
+
+	clock.Advance(8 * time.Minute)
+	if _, err := service.ValidateToken(token); !errors.Is(err, ErrTokenExpired) {
+		t.Errorf("ValidateToken() error = %v; want ErrTokenExpired", err)
+	}
+}
+
+func TestTokenClaims(t *testing.T) {
+	service := NewService()
+
+	roles := []string{"admin", "editor"}
+	token, err := service.GenerateTokenWithClaims(100, roles)
+	if err != nil {
+		t.Fatalf("GenerateTokenWithClaims() unexpected error: %v", err)
+	}
+
+	// Changing the caller's slice must not affect the stored claims
+	roles[0] = "guest"
+
+	userID, got, err := service.ValidateTokenClaims(token)
+	if err != nil {
+		t.Fatalf("ValidateTokenClaims() unexpected error: %v", err)
+	}
+	if userID != 100 {
+		t.Errorf("ValidateTokenClaims() userID = %d; want 100", userID)
+	}
+	if len(got) != 2 || got[0] != "admin" || got[1] != "editor" {
+		t.Errorf("ValidateTokenClaims() roles = %v; want [admin editor]", got)
+	}
+
+	// Roles survive a refresh
+	refreshed, err := service.RefreshToken(token)
+	if err != nil {
+		t.Fatalf("RefreshToken() unexpected error: %v", err)
+	}
+	if _, got, err := service.ValidateTokenClaims(refreshed); err != nil || len(got) != 2 {
+		t.Errorf("ValidateTokenClaims(refreshed) = (%v, %v); want 2 roles", got, err)
+	}
+}
+
+func TestTokenClaimsEmptyWithoutRoles(t *testing.T) {
+	service := NewService()
+
+	token, err := service.GenerateToken(1)
+	if err != nil {
+		t.Fatalf("GenerateToken(1) unexpected error: %v", err)
+	}
+
+	_, roles, err := service.ValidateTokenClaims(token)
+	if err != nil {
+		t.Fatalf("ValidateTokenClaims() unexpected error: %v", err)
+	}
+	if roles == nil || len(roles) != 0 {
+		t.Errorf("ValidateTokenClaims() roles = %#v; want empty non-nil slice", roles)
+	}
+
+	if _, _, err := service.ValidateTokenClaims("garbage"); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("ValidateTokenClaims(garbage) error = %v; want ErrInvalidToken", err)
+	}
+}
+
+// fakeUserStore is a UserStore that records the usernames it was asked for.
+type fakeUserStore struct {
+	users   map[string]userRecord
+	lookups []string
+	err     error
+}
+
+func (f *fakeUserStore) Lookup(username string) (int, string, error) {
+	f.lookups = append(f.lookups, username)
+	if f.err != nil {
+		return 0, "", f.err
+	}
+	user, exists := f.users[username]
+	if !exists {
+		return 0, "", ErrUserNotFound
+	}
+	return user.userID, user.passwordHash, nil
+}
+
+func TestAuthenticateDelegatesToStore(t *testing.T) {
+	hash, err := HashPassword("s3cret")
+	if err != nil {
+		t.Fatalf("HashPassword unexpected error: %v", err)
+	}
+	store := &fakeUserStore{users: map[string]userRecord{
+		"erin": {userID: 42, passwordHash: hash},
+	}}
+	service := NewServiceWithStore(store)
+
+	userID, err := service.Authenticate("erin", "s3cret")
+	if err != nil || userID != 42 {
+		t.Errorf("Authenticate(%q) = (%d, %v); want (42, nil)", "erin", userID, err)
+	}
+
+	if _, err := service.Authenticate("erin", "wrong"); err == nil {
+		t.Error("Authenticate with wrong password expected error but got none")
+	}
+
+	// Demo users are not available with a custom store
+	if _, err := service.Authenticate("alice", "password123"); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("Authenticate(%q) error = %v; want ErrUserNotFound", "alice", err)
+	}
+
+	want := []string{"erin", "erin", "alice"}
+	if strings.Join(store.lookups, ",") != strings.Join(want, ",") {
+		t.Errorf("store lookups = %v; want %v", store.lookups, want)
+	}
+}
+
+func TestAuthenticatePropagatesStoreError(t *testing.T) {
+	errBackend := errors.New("database unavailable")
+	service := NewServiceWithStore(&fakeUserStore{err: errBackend})
+
+	if _, err := service.Authenticate("erin", "s3cret"); !errors.Is(err, errBackend) {
+		t.Errorf("Authenticate() error = %v; want %v", err, errBackend)
+	}
+}
+
+func TestRegisterUserUnsupportedStore(t *testing.T) {
+	service := NewServiceWithStore(&fakeUserStore{})
+
+	if err := service.RegisterUser("erin", "s3cret"); err == nil {
+		t.Error("RegisterUser with read-only store expected error but got none")
+	}
+}
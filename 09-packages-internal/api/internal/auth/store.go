@@ -0,0 +1,84 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrUserNotFound is returned by a UserStore when the username is unknown.
+var ErrUserNotFound = errors.New("user not found")
+
+// UserStore looks up users for Authenticate. Implementations must be safe
+// for concurrent use.
+//
+// Lookup must return the password hash in the format HashPassword produces:
+// a hex-encoded salt and a hex-encoded SHA-256 of salt+password, joined as
+// "<salt hex>:<sha256 hex>". Any other format never matches a password.
+type UserStore interface {
+	Lookup(username string) (userID int, passwordHash string, err error)
+}
+
+// userRegistrar is implemented by stores that can add users.
+// RegisterUser only works when the service's store implements it.
+type userRegistrar interface {
+	Register(username, passwordHash string) (userID int, err error)
+}
+
+// userRecord holds a registered user's ID and salted password hash.
+type userRecord struct {
+	userID       int
+	passwordHash string
+}
+
+// memoryStore is the default in-memory UserStore.
+// In a real implementation, users would come from a database.
+type memoryStore struct {
+	mu         sync.RWMutex
+	users      map[string]userRecord // Registered users by username
+	nextUserID int                   // ID assigned to the next registered user
+}
+
+// newMemoryStore creates an empty in-memory user store.
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		users:      make(map[string]userRecord),
+		nextUserID: 1,
+	}
+}
+
+// Lookup returns the user's ID and password hash.
+func (m *memoryStore) Lookup(username string) (int, string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	user, exists := m.users[username]
+	if !exists {
+		return 0, "", ErrUserNotFound
+	}
+	return user.userID, user.passwordHash, nil
+}
+
+// Register stores a new user under the next free ID.
+func (m *memoryStore) Register(username, passwordHash string) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.users[username]; exists {
+		return 0, fmt.Errorf("user %q already exists", username)
+	}
+
+	userID := m.nextUserID
+	m.users[username] = userRecord{userID: userID, passwordHash: passwordHash}
+	m.nextUserID++
+	return userID, nil
+}
+
+// add stores a user with a fixed ID, keeping later registrations above it.
+func (m *memoryStore) add(username string, userID int, passwordHash string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.users[username] = userRecord{userID: userID, passwordHash: passwordHash}
+	m.nextUserID = max(m.nextUserID, userID+1)
+}
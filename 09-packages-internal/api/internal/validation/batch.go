@@ -0,0 +1,28 @@
+package validation
+
+// BatchResult holds the validation outcome for one row of a batch.
+type BatchResult struct {
+	Index  int     // Position of the row in the input slice
+	Errors []error // Field failures from ValidateUserInput, empty when valid
+}
+
+// Valid reports whether the row passed validation.
+func (r BatchResult) Valid() bool {
+	return len(r.Errors) == 0
+}
+
+// ValidateBatch validates every input, such as the rows of a signup CSV,
+// without stopping at the first invalid one. It returns one result per
+// input, in the same order.
+func (s *Service) ValidateBatch(inputs []UserInput) []BatchResult {
+	results := make([]BatchResult, len(inputs))
+
+	for i, input := range inputs {
+		results[i] = BatchResult{
+			Index:  i,
+			Errors: s.ValidateUserInput(input),
+		}
+	}
+
+	return results
+}
@@ -0,0 +1,37 @@
+package validation
+
+import "testing"
+
+func TestValidateBatch(t *testing.T) {
+	inputs := []UserInput{
+		{Username: "alice", Password: "Secur3!pass", Email: "alice@example.com"},
+		{Username: "b", Password: "Secur3!pass", Email: "bob@example.com"},
+		{Username: "carol", Password: "Secur3!pass", Email: "carol@example.com"},
+		{Username: "", Password: "weak", Email: "not-an-email"},
+		{Username: "erin", Password: "Secur3!pass", Email: "erin@"},
+	}
+	wantErrors := []int{0, 1, 0, 3, 1}
+
+	service := NewService()
+	results := service.ValidateBatch(inputs)
+
+	if len(results) != len(inputs) {
+		t.Fatalf("ValidateBatch returned %d results; want %d", len(results), len(inputs))
+	}
+
+	for i, result := range results {
+		if result.Index != i {
+			t.Errorf("results[%d].Index = %d; want %d", i, result.Index, i)
+		}
+		if len(result.Errors) != wantErrors[i] {
+			t.Errorf("results[%d] has %d errors (%v); want %d", i, len(result.Errors), result.Errors, wantErrors[i])
+		}
+		if result.Valid() != (wantErrors[i] == 0) {
+			t.Errorf("results[%d].Valid() = %t; want %t", i, result.Valid(), wantErrors[i] == 0)
+		}
+	}
+
+	if results := service.ValidateBatch(nil); len(results) != 0 {
+		t.Errorf("ValidateBatch(nil) returned %d results; want 0", len(results))
+	}
+}
@@ -0,0 +1,71 @@
+package validation
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// defaultBlacklist holds common passwords that satisfy the character-class
+// rules but appear near the top of every leaked-password list.
+var defaultBlacklist = []string{
+	"Password1!",
+	"Password123!",
+	"P@ssw0rd",
+	"P@ssw0rd1",
+	"Passw0rd!",
+	"Welcome1!",
+	"Welcome123!",
+	"Qwerty123!",
+	"Admin123!",
+	"Letmein1!",
+	"Iloveyou1!",
+	"Summer2024!",
+	"Winter2024!",
+	"Changeme1!",
+	"Abcd1234!",
+}
+
+// LoadBlacklist adds passwords read from r, one per line, to the list that
+// ValidatePassword rejects. Blank lines and lines starting with '#' are
+// skipped. Matching is case-insensitive.
+func (s *Service) LoadBlacklist(r io.Reader) error {
+	var passwords []string
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		passwords = append(passwords, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to load password blacklist: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.addToBlacklist(passwords)
+	return nil
+}
+
+// addToBlacklist stores passwords in lowercase for case-insensitive matching.
+// The caller must hold s.mu for writing.
+func (s *Service) addToBlacklist(passwords []string) {
+	for _, password := range passwords {
+		s.blacklist[strings.ToLower(password)] = struct{}{}
+	}
+}
+
+// isBlacklisted reports whether password matches a blacklisted password,
+// ignoring case.
+func (s *Service) isBlacklisted(password string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, listed := s.blacklist[strings.ToLower(password)]
+	return listed
+}
@@ -0,0 +1,65 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidatePasswordBlacklist(t *testing.T) {
+	tests := []struct {
+		password string
+		hasError bool
+	}{
+		{"Password1!", true},
+		{"PASSWORD1!x", false},
+		{"pASSWORD1!", true},
+		{"P@ssw0rd", true},
+		{"Secur3!pass", false},
+	}
+
+	service := NewService()
+
+	for _, test := range tests {
+		err := service.ValidatePassword(test.password)
+		blacklisted := err != nil && strings.Contains(err.Error(), "too common")
+		if blacklisted != test.hasError {
+			t.Errorf("ValidatePassword(%q) error = %v; blacklisted = %t, want %t",
+				test.password, err, blacklisted, test.hasError)
+		}
+	}
+}
+
+func TestLoadBlacklist(t *testing.T) {
+	service := NewService()
+
+	if err := service.ValidatePassword("Tr0ub4dor&3"); err != nil {
+		t.Fatalf("ValidatePassword before loading unexpected error: %v", err)
+	}
+
+	list := "# Site-specific passwords\n\ntr0ub4dor&3\n  Correct-Horse-7  \n"
+	if err := service.LoadBlacklist(strings.NewReader(list)); err != nil {
+		t.Fatalf("LoadBlacklist unexpected error: %v", err)
+	}
+
+	for _, password := range []string{"Tr0ub4dor&3", "Correct-Horse-7", "Password1!"} {
+		if err := service.ValidatePassword(password); err == nil {
+			t.Errorf("ValidatePassword(%q) expected error but got none", password)
+		}
+	}
+	if err := service.ValidatePassword("Secur3!pass"); err != nil {
+		t.Errorf("ValidatePassword(%q) unexpected error: %v", "Secur3!pass", err)
+	}
+}
+
+func TestValidateCredentialsAllowsBlacklisted(t *testing.T) {
+	service := NewService()
+
+	for _, password := range []string{"Password1!", "P@ssw0rd"} {
+		if err := service.ValidatePassword(password); err == nil {
+			t.Fatalf("ValidatePassword(%q) expected error", password)
+		}
+		if err := service.ValidateCredentials("alice", password); err != nil {
+			t.Errorf("ValidateCredentials(%q, %q) unexpected error: %v", "alice", password, err)
+		}
+	}
+}
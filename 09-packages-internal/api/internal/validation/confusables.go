@@ -0,0 +1,79 @@
+package validation
+
+import (
+	"fmt"
+	"unicode"
+)
+
+// ConfusableError reports a username character that could be used to
+// impersonate another user, such as an invisible space or a letter from a
+// different alphabet that looks like a Latin one.
+type ConfusableError struct {
+	Char     rune
+	Position int // 1-based character position within the username
+	Reason   string
+}
+
+func (e *ConfusableError) Error() string {
+	return fmt.Sprintf("username contains suspicious character %q (U+%04X) at position %d: %s",
+		e.Char, e.Char, e.Position, e.Reason)
+}
+
+// confusableScripts lists alphabets whose letters are commonly mistaken for
+// one another. Letters from other scripts are not compared.
+var confusableScripts = []struct {
+	name  string
+	table *unicode.RangeTable
+}{
+	{"Latin", unicode.Latin},
+	{"Greek", unicode.Greek},
+	{"Cyrillic", unicode.Cyrillic},
+	{"Armenian", unicode.Armenian},
+}
+
+// scriptOf returns the name of the confusable script containing char, or "".
+func scriptOf(char rune) string {
+	for _, script := range confusableScripts {
+		if unicode.Is(script.table, char) {
+			return script.name
+		}
+	}
+	return ""
+}
+
+// HasConfusables reports whether username contains invisible whitespace or
+// mixes look-alike alphabets, regardless of the DetectConfusables option.
+func (s *Service) HasConfusables(username string) bool {
+	return findConfusable(username) != nil
+}
+
+// findConfusable returns a *ConfusableError for the first invisible or
+// whitespace character, or the first letter whose script differs from the
+// earlier letters in username. It returns nil if nothing suspicious is found.
+func findConfusable(username string) error {
+	firstScript := ""
+	position := 0
+
+	for _, char := range username {
+		position++
+
+		if unicode.IsSpace(char) || unicode.Is(unicode.Cf, char) {
+			return &ConfusableError{Char: char, Position: position, Reason: "invisible or whitespace character"}
+		}
+
+		script := scriptOf(char)
+		if script == "" {
+			continue
+		}
+		if firstScript == "" {
+			firstScript = script
+			continue
+		}
+		if script != firstScript {
+			reason := fmt.Sprintf("%s letter mixed with %s letters", script, firstScript)
+			return &ConfusableError{Char: char, Position: position, Reason: reason}
+		}
+	}
+
+	return nil
+}
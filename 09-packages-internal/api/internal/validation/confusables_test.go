@@ -0,0 +1,79 @@
+package validation
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateUsernameConfusables(t *testing.T) {
+	tests := []struct {
+		username string
+		position int // 0 means the username is accepted
+	}{
+		{"alice", 0},
+		{"\u0430\u043b\u0438\u0441\u0430", 0}, // all Cyrillic
+		{"p\u0430ypal", 2},                    // Cyrillic U+0430 'a' among Latin letters
+		{"bob\u200bby", 4},                    // zero-width space
+		{"ali\u00a0ce", 4},                    // no-break space
+		{"user_42", 0},
+	}
+
+	service := NewService()
+	service.AllowUnicode = true
+	service.DetectConfusables = true
+
+	for _, test := range tests {
+		err := service.ValidateUsername(test.username)
+
+		if test.position == 0 {
+			if err != nil {
+				t.Errorf("ValidateUsername(%q) unexpected error: %v", test.username, err)
+			}
+			continue
+		}
+
+		var confusable *ConfusableError
+		if !errors.As(err, &confusable) {
+			t.Errorf("ValidateUsername(%q) error = %v; want *ConfusableError", test.username, err)
+			continue
+		}
+		if confusable.Position != test.position {
+			t.Errorf("ValidateUsername(%q) position = %d; want %d", test.username, confusable.Position, test.position)
+		}
+	}
+}
+
+func TestValidateUsernameConfusablesOptIn(t *testing.T) {
+	service := NewService()
+	service.AllowUnicode = true
+
+	// Without the option, mixed scripts are still letters and are accepted
+	if err := service.ValidateUsername("p\u0430ypal"); err != nil {
+		t.Errorf("ValidateUsername with detection disabled unexpected error: %v", err)
+	}
+}
+
+func TestHasConfusables(t *testing.T) {
+	tests := []struct {
+		username string
+		want     bool
+	}{
+		{"alice", false},
+		{"Alice_Smith42", false},
+		{"\u0430\u043b\u0438\u0441\u0430", false}, // all Cyrillic
+		{"\u03b1\u03bb\u03c6\u03b1", false},       // all Greek
+		{"p\u0430ypal", true},                     // Latin with Cyrillic U+0430 'a'
+		{"\u0430dmin", true},                      // Cyrillic U+0430 'a' first, then Latin
+		{"b\u03bfb", true},                        // Greek omicron between Latin letters
+		{"bob\u200bby", true},                     // zero-width space
+		{"user_42", false},
+	}
+
+	service := NewService()
+
+	for _, test := range tests {
+		if got := service.HasConfusables(test.username); got != test.want {
+			t.Errorf("HasConfusables(%q) = %t; want %t", test.username, got, test.want)
+		}
+	}
+}
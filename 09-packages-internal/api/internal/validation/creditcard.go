@@ -0,0 +1,57 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidateCreditCard validates a card number's length and Luhn checksum.
+// Spaces and dashes used to group digits are ignored.
+func (s *Service) ValidateCreditCard(number string) error {
+	digits := strings.NewReplacer(" ", "", "-", "").Replace(number)
+
+	if digits == "" {
+		return fmt.Errorf("card number cannot be empty")
+	}
+
+	// Positions count characters in the number as typed, separators included
+	position := 0
+	for _, char := range number {
+		position++
+		if char != ' ' && char != '-' && (char < '0' || char > '9') {
+			return fmt.Errorf("card number contains invalid character %q at position %d", char, position)
+		}
+	}
+
+	if len(digits) < 13 || len(digits) > 19 {
+		return fmt.Errorf("card number must be 13 to 19 digits long, got %d", len(digits))
+	}
+
+	if !luhnValid(digits) {
+		return fmt.Errorf("card number failed checksum validation")
+	}
+
+	return nil
+}
+
+// luhnValid reports whether a string of ASCII digits passes the Luhn check.
+// Starting from the rightmost digit, every second digit is doubled and the
+// digits of the products are summed; valid numbers total a multiple of 10.
+func luhnValid(digits string) bool {
+	sum := 0
+	double := false
+
+	for i := len(digits) - 1; i >= 0; i-- {
+		digit := int(digits[i] - '0')
+		if double {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+		double = !double
+	}
+
+	return sum%10 == 0
+}
@@ -0,0 +1,67 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateCreditCard(t *testing.T) {
+	tests := []struct {
+		number   string
+		hasError bool
+	}{
+		// Well-known test card numbers
+		{"4111111111111111", false},    // Visa
+		{"4111 1111 1111 1111", false}, // Visa, grouped with spaces
+		{"5555-5555-5555-4444", false}, // Mastercard, grouped with dashes
+		{"378282246310005", false},     // American Express
+		{"6011111111111117", false},    // Discover
+		{"4222222222222", false},       // 13-digit Visa
+		{"6062826786276634", false},    // Hipercard
+
+		// Corrupted numbers
+		{"4111111111111112", true},     // Last digit changed
+		{"4111111111111121", true},     // Adjacent digits swapped
+		{"5555-5555-5555-4445", true},  // Last digit changed
+		{"411111111111", true},         // Too short
+		{"41111111111111111111", true}, // Too long
+		{"4111-1111-1111-111a", true},  // Letter
+		{"4111.1111.1111.1111", true},  // Unsupported separator
+		{"", true},
+		{" - ", true},
+	}
+
+	service := NewService()
+
+	for _, test := range tests {
+		err := service.ValidateCreditCard(test.number)
+		if test.hasError && err == nil {
+			t.Errorf("ValidateCreditCard(%q) expected error but got none", test.number)
+		}
+		if !test.hasError && err != nil {
+			t.Errorf("ValidateCreditCard(%q) unexpected error: %v", test.number, err)
+		}
+	}
+}
+
+func TestValidateCreditCardInvalidCharacterPosition(t *testing.T) {
+	tests := []struct {
+		number  string
+		message string
+	}{
+		{"4111111111111x11", `invalid character 'x' at position 14`},
+		{"4111-1111-1111-111a", `invalid character 'a' at position 19`},
+		{"4111 1111 x111 1111", `invalid character 'x' at position 11`},
+		{"4111.1111.1111.1111", `invalid character '.' at position 5`},
+		{"4111 1111 1111 11\u00e91", "invalid character '\u00e9' at position 18"},
+	}
+
+	service := NewService()
+
+	for _, test := range tests {
+		err := service.ValidateCreditCard(test.number)
+		if err == nil || !strings.Contains(err.Error(), test.message) {
+			t.Errorf("ValidateCreditCard(%q) error = %v; want it to contain %q", test.number, err, test.message)
+		}
+	}
+}
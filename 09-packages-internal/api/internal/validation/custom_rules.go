@@ -0,0 +1,46 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnknownRule is returned by Validate for a rule name that was never registered.
+var ErrUnknownRule = errors.New("unknown validation rule")
+
+// RegisterRule adds a named, domain-specific check such as "sku" or "slug"
+// that can later be run with Validate. Names must be unique.
+func (s *Service) RegisterRule(name string, fn func(string) error) error {
+	if name == "" {
+		return fmt.Errorf("rule name cannot be empty")
+	}
+	if fn == nil {
+		return fmt.Errorf("rule %q has no validation function", name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.rules[name]; exists {
+		return fmt.Errorf("rule %q is already registered", name)
+	}
+
+	s.rules[name] = fn
+	return nil
+}
+
+// Validate runs the named rule against value.
+func (s *Service) Validate(name, value string) error {
+	s.mu.RLock()
+	fn, exists := s.rules[name]
+	s.mu.RUnlock()
+
+	if !exists {
+		return fmt.Errorf("%w: %q", ErrUnknownRule, name)
+	}
+
+	if err := fn(value); err != nil {
+		return fmt.Errorf("%s validation failed: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+func TestRegisterRuleAndValidate(t *testing.T) {
+	skuPattern := regexp.MustCompile(`^[A-Z]{3}-\d{4}$`)
+	errBadSKU := errors.New("must look like ABC-1234")
+
+	service := NewService()
+	err := service.RegisterRule("sku", func(value string) error {
+		if !skuPattern.MatchString(value) {
+			return errBadSKU
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("RegisterRule(%q) unexpected error: %v", "sku", err)
+	}
+
+	tests := []struct {
+		value    string
+		hasError bool
+	}{
+		{"ABC-1234", false},
+		{"abc-1234", true},
+		{"ABC1234", true},
+		{"", true},
+	}
+
+	for _, test := range tests {
+		err := service.Validate("sku", test.value)
+		if test.hasError && !errors.Is(err, errBadSKU) {
+			t.Errorf("Validate(%q, %q) error = %v; want %v", "sku", test.value, err, errBadSKU)
+		}
+		if !test.hasError && err != nil {
+			t.Errorf("Validate(%q, %q) unexpected error: %v", "sku", test.value, err)
+		}
+	}
+}
+
+func TestValidateUnknownRule(t *testing.T) {
+	service := NewService()
+
+	if err := service.Validate("slug", "hello-world"); !errors.Is(err, ErrUnknownRule) {
+		t.Errorf("Validate(%q) error = %v; want ErrUnknownRule", "slug", err)
+	}
+}
+
+func TestRegisterRuleRejectsDuplicates(t *testing.T) {
+	service := NewService()
+	first := func(string) error { return nil }
+	second := func(string) error { return fmt.Errorf("always fails") }
+
+	if err := service.RegisterRule("slug", first); err != nil {
+		t.Fatalf("RegisterRule(%q) unexpected error: %v", "slug", err)
+	}
+	if err := service.RegisterRule("slug", second); err == nil {
+		t.Error("RegisterRule with duplicate name expected error but got none")
+	}
+
+	// The original rule is kept
+	if err := service.Validate("slug", "anything"); err != nil {
+		t.Errorf("Validate(%q) unexpected error: %v", "slug", err)
+	}
+
+	if err := service.RegisterRule("", first); err == nil {
+		t.Error("RegisterRule with empty name expected error but got none")
+	}
+	if err := service.RegisterRule("nil", nil); err == nil {
+		t.Error("RegisterRule with nil function expected error but got none")
+	}
+}
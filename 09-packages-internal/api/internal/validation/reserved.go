@@ -0,0 +1,42 @@
+package validation
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrReservedUsername is returned by ValidateUsername for names that are
+// reserved for the system or staff.
+var ErrReservedUsername = errors.New("username is reserved")
+
+// defaultReservedUsernames are the names reserved by NewService.
+var defaultReservedUsernames = []string{
+	"admin",
+	"administrator",
+	"root",
+	"support",
+	"system",
+}
+
+// SetReservedUsernames replaces the set of names that ValidateUsername
+// rejects. Matching is case-insensitive; an empty list reserves nothing.
+func (s *Service) SetReservedUsernames(names []string) {
+	reserved := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		reserved[strings.ToLower(strings.TrimSpace(name))] = struct{}{}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.reserved = reserved
+}
+
+// isReserved reports whether username matches a reserved name, ignoring case.
+func (s *Service) isReserved(username string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, reserved := s.reserved[strings.ToLower(username)]
+	return reserved
+}
@@ -0,0 +1,67 @@
+package validation
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateUsernameReserved(t *testing.T) {
+	tests := []struct {
+		username string
+		reserved bool
+	}{
+		{"admin", true},
+		{"Admin", true},
+		{"ROOT", true},
+		{"support", true},
+		{"admin2", false},
+		{"administrator_bob", false},
+		{"rooted", false},
+		{"alice", false},
+	}
+
+	service := NewService()
+
+	for _, test := range tests {
+		err := service.ValidateUsername(test.username)
+		if test.reserved && !errors.Is(err, ErrReservedUsername) {
+			t.Errorf("ValidateUsername(%q) error = %v; want ErrReservedUsername", test.username, err)
+		}
+		if !test.reserved && err != nil {
+			t.Errorf("ValidateUsername(%q) unexpected error: %v", test.username, err)
+		}
+	}
+}
+
+func TestSetReservedUsernames(t *testing.T) {
+	service := NewService()
+	service.SetReservedUsernames([]string{"Billing", "staff"})
+
+	if err := service.ValidateUsername("billing"); !errors.Is(err, ErrReservedUsername) {
+		t.Errorf("ValidateUsername(%q) error = %v; want ErrReservedUsername", "billing", err)
+	}
+	if err := service.ValidateUsername("STAFF"); !errors.Is(err, ErrReservedUsername) {
+		t.Errorf("ValidateUsername(%q) error = %v; want ErrReservedUsername", "STAFF", err)
+	}
+
+	// The default names are replaced, not extended
+	if err := service.ValidateUsername("admin"); err != nil {
+		t.Errorf("ValidateUsername(%q) unexpected error: %v", "admin", err)
+	}
+
+	service.SetReservedUsernames(nil)
+	if err := service.ValidateUsername("billing"); err != nil {
+		t.Errorf("ValidateUsername(%q) after clearing unexpected error: %v", "billing", err)
+	}
+}
+
+func TestValidateCredentialsAllowsReserved(t *testing.T) {
+	service := NewService()
+
+	// Logging in to an existing account with a reserved name must still work
+	for _, username := range []string{"admin", "Root"} {
+		if err := service.ValidateCredentials(username, "Sup3r$ecret!"); err != nil {
+			t.Errorf("ValidateCredentials(%q) unexpected error: %v", username, err)
+		}
+	}
+}
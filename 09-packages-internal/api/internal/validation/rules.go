@@ -1,16 +1,34 @@
 package validation
 
 import (
+	"errors"
 	"fmt"
+	"net/url"
 	"regexp"
 	"strings"
+	"sync"
 	"unicode"
+	"unicode/utf8"
 )
 
 // Service provides input validation functionality.
 // This is internal to the api package and cannot be imported by external packages.
 type Service struct {
 	emailRegex *regexp.Regexp
+
+	mu        sync.RWMutex                  // Guards rules, blacklist and reserved
+	rules     map[string]func(string) error // Custom rules added with RegisterRule
+	blacklist map[string]struct{}           // Lowercased passwords that are too common to allow
+	reserved  map[string]struct{}           // Lowercased usernames that cannot be claimed
+
+	// DetectConfusables makes ValidateUsername reject invisible whitespace and
+	// usernames that mix look-alike alphabets, such as a Cyrillic U+0430 'a' among Latin letters.
+	DetectConfusables bool
+
+	// AllowUnicode lets usernames use letters and digits from any script, such
+	// as "алиса" or "太郎". By default only ASCII letters and digits are allowed.
+	// Symbols and control characters are rejected either way.
+	AllowUnicode bool
 }
 
 // NewService creates a new validation service.
@@ -18,57 +36,114 @@ func NewService() *Service {
 	// Compile email validation regex once
 	emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
 
-	return &Service{
+	service := &Service{
 		emailRegex: emailRegex,
+		rules:      make(map[string]func(string) error),
+		blacklist:  make(map[string]struct{}),
 	}
+	service.addToBlacklist(defaultBlacklist)
+	service.SetReservedUsernames(defaultReservedUsernames)
+	return service
 }
 
 // ValidateCredentials validates username and password for authentication.
+// Reserved names and blacklisted passwords are not rejected here, so existing
+// accounts can still log in; ValidateUsername and ValidatePassword apply those
+// checks when names are claimed and passwords are set.
 func (s *Service) ValidateCredentials(username, password string) error {
-	if err := s.ValidateUsername(username); err != nil {
+	if err := s.validateUsernameFormat(username); err != nil {
 		return fmt.Errorf("username validation failed: %w", err)
 	}
 
-	if err := s.ValidatePassword(password); err != nil {
+	if err := validatePasswordFormat(password); err != nil {
 		return fmt.Errorf("password validation failed: %w", err)
 	}
 
 	return nil
 }
 
-// ValidateUsername validates a username according to business rules.
+// ValidateUsername validates a username according to business rules,
+// including that it is not reserved. Use it where usernames are created.
 func (s *Service) ValidateUsername(username string) error {
+	if err := s.validateUsernameFormat(username); err != nil {
+		return err
+	}
+
+	if s.isReserved(strings.TrimSpace(username)) {
+		return fmt.Errorf("%w: %q", ErrReservedUsername, strings.TrimSpace(username))
+	}
+
+	return nil
+}
+
+// validateUsernameFormat checks a username's length and characters.
+func (s *Service) validateUsernameFormat(username string) error {
 	username = strings.TrimSpace(username)
 
 	if username == "" {
 		return fmt.Errorf("username cannot be empty")
 	}
 
-	if len(username) < 3 {
+	length := utf8.RuneCountInString(username)
+
+	if length < 3 {
 		return fmt.Errorf("username must be at least 3 characters long")
 	}
 
-	if len(username) > 50 {
+	if length > 50 {
 		return fmt.Errorf("username must be no more than 50 characters long")
 	}
 
+	if s.DetectConfusables {
+		if err := findConfusable(username); err != nil {
+			return err
+		}
+	}
+
 	// Check for valid characters (alphanumeric and underscore only)
 	for _, char := range username {
-		if !unicode.IsLetter(char) && !unicode.IsDigit(char) && char != '_' {
+		if !s.isUsernameChar(char) {
 			return fmt.Errorf("username can only contain letters, numbers, and underscores")
 		}
 	}
 
 	// Username must start with a letter
-	if !unicode.IsLetter(rune(username[0])) {
+	if first, _ := utf8.DecodeRuneInString(username); !unicode.IsLetter(first) {
 		return fmt.Errorf("username must start with a letter")
 	}
 
 	return nil
 }
 
-// ValidatePassword validates a password according to security requirements.
+// isUsernameChar reports whether char may appear in a username.
+func (s *Service) isUsernameChar(char rune) bool {
+	if char == '_' {
+		return true
+	}
+
+	if s.AllowUnicode {
+		return unicode.IsLetter(char) || unicode.IsDigit(char)
+	}
+
+	return ('a' <= char && char <= 'z') || ('A' <= char && char <= 'Z') || ('0' <= char && char <= '9')
+}
+
+// ValidatePassword validates a password according to security requirements,
+// including that it is not blacklisted. Use it where passwords are set.
 func (s *Service) ValidatePassword(password string) error {
+	if err := validatePasswordFormat(password); err != nil {
+		return err
+	}
+
+	if s.isBlacklisted(password) {
+		return fmt.Errorf("password is too common, please choose another")
+	}
+
+	return nil
+}
+
+// validatePasswordFormat checks a password's length and character classes.
+func validatePasswordFormat(password string) error {
 	if password == "" {
 		return fmt.Errorf("password cannot be empty")
 	}
@@ -135,6 +210,30 @@ func (s *Service) ValidateEmail(email string) error {
 	return nil
 }
 
+// ValidateURL validates an absolute http or https URL with a host.
+func (s *Service) ValidateURL(raw string) error {
+	raw = strings.TrimSpace(raw)
+
+	if raw == "" {
+		return fmt.Errorf("URL cannot be empty")
+	}
+
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("URL format is invalid: %w", err)
+	}
+
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return fmt.Errorf("URL scheme must be http or https, got %q", parsed.Scheme)
+	}
+
+	if parsed.Host == "" {
+		return fmt.Errorf("URL must include a host")
+	}
+
+	return nil
+}
+
 // ValidateUserInput validates a complete user input structure.
 type UserInput struct {
 	Username string `json:"username"`
@@ -161,6 +260,35 @@ func (s *Service) ValidateUserInput(input UserInput) []error {
 	return errors
 }
 
+// Field errors wrapped by ValidateUserInputErr, for use with errors.Is.
+var (
+	ErrInvalidUsername = errors.New("invalid username")
+	ErrInvalidPassword = errors.New("invalid password")
+	ErrInvalidEmail    = errors.New("invalid email")
+)
+
+// ValidateUserInputErr validates all fields like ValidateUserInput but
+// returns nil or a single error joining every field failure. Each failure
+// wraps both its field error (such as ErrInvalidEmail) and the underlying
+// cause, so callers can inspect it with errors.Is and errors.As.
+func (s *Service) ValidateUserInputErr(input UserInput) error {
+	var errs []error
+
+	if err := s.ValidateUsername(input.Username); err != nil {
+		errs = append(errs, fmt.Errorf("%w: %w", ErrInvalidUsername, err))
+	}
+
+	if err := s.ValidatePassword(input.Password); err != nil {
+		errs = append(errs, fmt.Errorf("%w: %w", ErrInvalidPassword, err))
+	}
+
+	if err := s.ValidateEmail(input.Email); err != nil {
+		errs = append(errs, fmt.Errorf("%w: %w", ErrInvalidEmail, err))
+	}
+
+	return errors.Join(errs...)
+}
+
 // ValidateRequired checks if a value is not empty (for string fields).
 func (s *Service) ValidateRequired(fieldName, value string) error {
 	if strings.TrimSpace(value) == "" {
@@ -0,0 +1,110 @@
+package validation
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateURL(t *testing.T) {
+	tests := []struct {
+		raw      string
+		hasError bool
+	}{
+		{"https://example.com", false},
+		{"http://example.com/path/to/page", false},
+		{"https://example.com:8443/search?q=go&page=2#results", false},
+		{"  https://example.com  ", false},
+		{"", true},
+		{"   ", true},
+		{"not a url", true},
+		{"ftp://x", true},
+		{"example.com", true},
+		{"https://", true},
+		{"https:///path", true},
+		{"mailto:user@example.com", true},
+		{"http://[::1", true},
+	}
+
+	service := NewService()
+
+	for _, test := range tests {
+		err := service.ValidateURL(test.raw)
+		if test.hasError && err == nil {
+			t.Errorf("ValidateURL(%q) expected error but got none", test.raw)
+		}
+		if !test.hasError && err != nil {
+			t.Errorf("ValidateURL(%q) unexpected error: %v", test.raw, err)
+		}
+	}
+}
+
+func TestValidateUserInputErr(t *testing.T) {
+	service := NewService()
+
+	valid := UserInput{Username: "alice", Password: "Secur3!pass", Email: "alice@example.com"}
+	if err := service.ValidateUserInputErr(valid); err != nil {
+		t.Errorf("ValidateUserInputErr(valid) unexpected error: %v", err)
+	}
+
+	badEmail := valid
+	badEmail.Email = "not-an-email"
+	err := service.ValidateUserInputErr(badEmail)
+	if !errors.Is(err, ErrInvalidEmail) {
+		t.Errorf("ValidateUserInputErr(bad email) error = %v; want ErrInvalidEmail", err)
+	}
+	if errors.Is(err, ErrInvalidUsername) || errors.Is(err, ErrInvalidPassword) {
+		t.Errorf("ValidateUserInputErr(bad email) error = %v; want only the email to fail", err)
+	}
+
+	allBad := UserInput{Username: "p\u0430ypal", Password: "short", Email: ""}
+	service.DetectConfusables = true
+	err = service.ValidateUserInputErr(allBad)
+	for _, target := range []error{ErrInvalidUsername, ErrInvalidPassword, ErrInvalidEmail} {
+		if !errors.Is(err, target) {
+			t.Errorf("ValidateUserInputErr(all bad) error = %v; want it to wrap %v", err, target)
+		}
+	}
+
+	var confusable *ConfusableError
+	if !errors.As(err, &confusable) {
+		t.Errorf("ValidateUserInputErr(all bad) error = %v; want it to wrap *ConfusableError", err)
+	}
+
+	if got := len(service.ValidateUserInput(allBad)); got != 3 {
+		t.Errorf("ValidateUserInput(all bad) returned %d errors; want 3", got)
+	}
+}
+
+func TestValidateUsernameAllowUnicode(t *testing.T) {
+	tests := []struct {
+		username     string
+		validASCII   bool
+		validUnicode bool
+	}{
+		{"alice_42", true, true},
+		{"\u0430\u043b\u0438\u0441\u0430", false, true}, // Cyrillic "alisa"
+		{"太郎さん", false, true},
+		{"José", false, true},
+		{"مستخدم", false, true},
+		{"日本", false, false},       // Too short: 2 characters
+		{"1太郎", false, false},      // Must start with a letter
+		{"太郎★", false, false},      // Symbol
+		{"太郎\u0007", false, false}, // Control character
+		{"ali ce", false, false},
+	}
+
+	ascii := NewService()
+	unicodeService := NewService()
+	unicodeService.AllowUnicode = true
+
+	for _, test := range tests {
+		if err := ascii.ValidateUsername(test.username); (err == nil) != test.validASCII {
+			t.Errorf("ValidateUsername(%q) with AllowUnicode off error = %v; want valid = %t",
+				test.username, err, test.validASCII)
+		}
+		if err := unicodeService.ValidateUsername(test.username); (err == nil) != test.validUnicode {
+			t.Errorf("ValidateUsername(%q) with AllowUnicode on error = %v; want valid = %t",
+				test.username, err, test.validUnicode)
+		}
+	}
+}
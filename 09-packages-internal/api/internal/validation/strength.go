@@ -0,0 +1,85 @@
+package validation
+
+import "unicode"
+
+// Password strength labels returned by PasswordStrength.
+const (
+	StrengthWeak   = "weak"
+	StrengthFair   = "fair"
+	StrengthStrong = "strong"
+)
+
+// Scoring weights for PasswordStrength. Length and character-class
+// diversity together add up to a maximum of 100.
+const (
+	pointsPerRune      = 3  // For each of the first fullLengthRunes runes
+	pointsPerExtraRune = 1  // For each rune beyond fullLengthRunes, up to maxLengthRunes
+	fullLengthRunes    = 12 // Runes that earn full length points
+	maxLengthRunes     = 24 // Runes beyond this earn nothing
+	pointsPerClass     = 13 // For each of lowercase, uppercase, digit and special
+	patternPenalty     = 5  // For each repeated or sequential rune
+)
+
+// PasswordStrength scores a password from 0 to 100 for a strength meter.
+// Length and the number of character classes raise the score; repeated
+// characters ("aa") and runs ("abc", "321") lower it. The label is "weak"
+// below 40, "fair" below 70 and "strong" otherwise.
+func (s *Service) PasswordStrength(password string) (score int, label string) {
+	runes := []rune(password)
+
+	length := min(len(runes), fullLengthRunes)
+	extra := min(max(len(runes)-fullLengthRunes, 0), maxLengthRunes-fullLengthRunes)
+	score = length*pointsPerRune + extra*pointsPerExtraRune
+
+	var hasLower, hasUpper, hasDigit, hasSpecial bool
+	for i, char := range runes {
+		switch {
+		case unicode.IsLower(char):
+			hasLower = true
+		case unicode.IsUpper(char):
+			hasUpper = true
+		case unicode.IsDigit(char):
+			hasDigit = true
+		case unicode.IsPunct(char) || unicode.IsSymbol(char):
+			hasSpecial = true
+		}
+
+		if i > 0 && continuesPattern(runes[i-1], char) {
+			score -= patternPenalty
+		}
+	}
+
+	for _, present := range []bool{hasLower, hasUpper, hasDigit, hasSpecial} {
+		if present {
+			score += pointsPerClass
+		}
+	}
+
+	score = min(max(score, 0), 100)
+	return score, strengthLabel(score)
+}
+
+// continuesPattern reports whether current repeats prev or continues an
+// ascending or descending letter or digit run from it, ignoring case.
+func continuesPattern(prev, current rune) bool {
+	prev, current = unicode.ToLower(prev), unicode.ToLower(current)
+	if prev == current {
+		return true
+	}
+
+	sameKind := (unicode.IsLetter(prev) && unicode.IsLetter(current)) ||
+		(unicode.IsDigit(prev) && unicode.IsDigit(current))
+	return sameKind && (current == prev+1 || current == prev-1)
+}
+
+// strengthLabel maps a strength score to its label.
+func strengthLabel(score int) string {
+	switch {
+	case score < 40:
+		return StrengthWeak
+	case score < 70:
+		return StrengthFair
+	default:
+		return StrengthStrong
+	}
+}
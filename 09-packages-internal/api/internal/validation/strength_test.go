@@ -0,0 +1,91 @@
+package validation
+
+import "testing"
+
+func TestPasswordStrengthImprovesWithComplexity(t *testing.T) {
+	passwords := []string{
+		"",
+		"x",
+		"xk",
+		"xkq9",
+		"xkq9Tm",
+		"xkq9Tm#w",
+		"xkq9Tm#wLp2!vR",
+		"xkq9Tm#wLp2!vRj7&nBz4@hD",
+	}
+
+	service := NewService()
+
+	previous := -1
+	for _, password := range passwords {
+		score, _ := service.PasswordStrength(password)
+		if score <= previous {
+			t.Errorf("PasswordStrength(%q) = %d; want more than %d", password, score, previous)
+		}
+		if score < 0 || score > 100 {
+			t.Errorf("PasswordStrength(%q) = %d; want a score from 0 to 100", password, score)
+		}
+		previous = score
+	}
+}
+
+func TestPasswordStrengthPenalties(t *testing.T) {
+	tests := []struct {
+		weaker   string
+		stronger string
+	}{
+		{"aaaaaaaa", "akqmzwpt"},
+		{"abcdefgh", "akqmzwpt"},
+		{"Pass1234!", "Pass1739!"},
+		{"Pass9876!", "Pass1739!"},
+		{"PaSsWoRd1!", "PaXsWoRd1!"},
+	}
+
+	service := NewService()
+
+	for _, test := range tests {
+		weaker, _ := service.PasswordStrength(test.weaker)
+		stronger, _ := service.PasswordStrength(test.stronger)
+		if weaker >= stronger {
+			t.Errorf("PasswordStrength(%q) = %d; want less than PasswordStrength(%q) = %d",
+				test.weaker, weaker, test.stronger, stronger)
+		}
+	}
+}
+
+func TestPasswordStrengthLabels(t *testing.T) {
+	tests := []struct {
+		score int
+		label string
+	}{
+		{0, StrengthWeak},
+		{39, StrengthWeak},
+		{40, StrengthFair},
+		{69, StrengthFair},
+		{70, StrengthStrong},
+		{100, StrengthStrong},
+	}
+
+	for _, test := range tests {
+		if label := strengthLabel(test.score); label != test.label {
+			t.Errorf("strengthLabel(%d) = %q; want %q", test.score, label, test.label)
+		}
+	}
+
+	service := NewService()
+	examples := []struct {
+		password string
+		label    string
+	}{
+		{"password", StrengthWeak},
+		{"Password1", StrengthFair},
+		{"xkq9Tm#wLp2!vR", StrengthStrong},
+	}
+
+	for _, example := range examples {
+		score, label := service.PasswordStrength(example.password)
+		if label != example.label {
+			t.Errorf("PasswordStrength(%q) = (%d, %q); want label %q", example.password, score, label, example.label)
+		}
+	}
+}
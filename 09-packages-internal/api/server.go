@@ -1,6 +1,7 @@
 package api
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -8,24 +9,47 @@ import (
 
 	"go-fast/09-packages-internal/api/internal/auth"
 	"go-fast/09-packages-internal/api/internal/validation"
+	"go-fast/09-packages-internal/internal/config"
 	"go-fast/09-packages-internal/internal/shared"
 )
 
+// maxRequestBodyBytes caps the size of JSON request bodies.
+const maxRequestBodyBytes = 1 << 20 // 1 MiB
+
 // Server represents the API server with internal dependencies.
 type Server struct {
-	authenticator *auth.Service
-	validator     *validation.Service
-	logger        func(string, ...interface{})
+	authenticator  *auth.Service
+	validator      *validation.Service
+	logger         func(string, ...interface{})
+	debug          bool
+	allowedOrigins []string // Origins permitted for cross-origin requests
 }
 
 // NewServer creates a new API server instance.
 // This demonstrates how internal packages are used within the parent package.
 func NewServer() *Server {
 	return &Server{
-		authenticator: auth.NewService(),
-		validator:     validation.NewService(),
-		logger:        log.Printf,
+		authenticator:  auth.NewService(),
+		validator:      validation.NewService(),
+		logger:         log.Printf,
+		allowedOrigins: []string{"*"},
+	}
+}
+
+// NewServerWithConfig creates a new API server configured from cfg.
+// Debug-only endpoints such as /echo are registered only when cfg.Debug is set,
+// issued tokens last cfg.TokenTTL when it is set, and cross-origin requests are
+// limited to cfg.AllowedOrigins when it is set.
+func NewServerWithConfig(cfg *config.Config) *Server {
+	server := NewServer()
+	server.debug = cfg.Debug
+	if cfg.TokenTTL > 0 {
+		server.authenticator = auth.NewServiceWithTTL(cfg.TokenTTL)
+	}
+	if len(cfg.AllowedOrigins) > 0 {
+		server.allowedOrigins = cfg.AllowedOrigins
 	}
+	return server
 }
 
 // LoginRequest represents the login request payload.
@@ -44,12 +68,12 @@ type LoginResponse struct {
 // This demonstrates how the public API uses internal services.
 func (s *Server) HandleLogin(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		shared.WriteJSONError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		shared.WriteCodedError(w, shared.CodeMethodNotAllowed)
 		return
 	}
 
 	var req LoginRequest
-	if err := shared.ParseJSONBody(r, &req); err != nil {
+	if err := shared.ParseJSONBodyLimited(r, &req, maxRequestBodyBytes); err != nil {
 		s.logger("Login parse error: %v", err)
 		shared.WriteJSONError(w, http.StatusBadRequest, "Invalid request body")
 		return
@@ -74,7 +98,7 @@ func (s *Server) HandleLogin(w http.ResponseWriter, r *http.Request) {
 	token, err := s.authenticator.GenerateToken(userID)
 	if err != nil {
 		s.logger("Token generation failed for user ID %d: %v", userID, err)
-		shared.WriteJSONError(w, http.StatusInternalServerError, "Failed to generate token")
+		s.writeServerError(w, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -94,21 +118,19 @@ func (s *Server) HandleLogin(w http.ResponseWriter, r *http.Request) {
 // HandleValidateToken handles token validation requests.
 func (s *Server) HandleValidateToken(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		shared.WriteJSONError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		shared.WriteCodedError(w, shared.CodeMethodNotAllowed)
 		return
 	}
 
 	// Extract token from Authorization header
-	authHeader := r.Header.Get("Authorization")
-	if authHeader == "" {
+	token, err := shared.ParseBearerToken(r)
+	if errors.Is(err, shared.ErrMissingAuthorization) {
 		shared.WriteJSONError(w, http.StatusBadRequest, "Authorization header required")
 		return
 	}
-
-	// Simple token extraction (in production, use proper Bearer token parsing)
-	token := authHeader
-	if len(authHeader) > 7 && authHeader[:7] == "Bearer " {
-		token = authHeader[7:]
+	if err != nil {
+		shared.WriteJSONError(w, http.StatusUnauthorized, "Invalid authorization header")
+		return
 	}
 
 	// Validate token using internal auth service
@@ -133,7 +155,7 @@ func (s *Server) HandleValidateToken(w http.ResponseWriter, r *http.Request) {
 // HandleStatus provides server status information.
 func (s *Server) HandleStatus(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		shared.WriteJSONError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		shared.WriteCodedError(w, shared.CodeMethodNotAllowed)
 		return
 	}
 
@@ -152,26 +174,62 @@ func (s *Server) HandleStatus(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// HandleEcho parses the JSON request body and echoes it back unchanged.
+// Decode errors are reported verbatim so clients can see exactly how the
+// server interprets a payload. It is only routed in debug mode.
+func (s *Server) HandleEcho(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		shared.WriteCodedError(w, shared.CodeMethodNotAllowed)
+		return
+	}
+
+	var payload interface{}
+	if err := shared.ParseJSONBodyLimited(r, &payload, maxRequestBodyBytes); err != nil {
+		shared.WriteJSONErrorWithDetails(w, http.StatusBadRequest, "Invalid request body", err.Error())
+		return
+	}
+
+	if err := shared.WriteJSONResponse(w, http.StatusOK, payload); err != nil {
+		s.logger("Failed to write echo response: %v", err)
+	}
+}
+
+// writeServerError writes a 5xx JSON error response.
+// The underlying cause is only included in debug mode so that production
+// responses never disclose internal details.
+func (s *Server) writeServerError(w http.ResponseWriter, statusCode int, err error) {
+	if s.debug {
+		shared.WriteJSONErrorWithDetails(w, statusCode, "internal error", err.Error())
+		return
+	}
+	shared.WriteJSONError(w, statusCode, "internal error")
+}
+
 // SetupRoutes configures the HTTP routes for the server.
 func (s *Server) SetupRoutes() *http.ServeMux {
 	mux := http.NewServeMux()
 
-	// Apply logging middleware to all routes
-	loggingMiddleware := shared.LoggingMiddleware(s.logger)
-
-	mux.Handle("/login", loggingMiddleware(http.HandlerFunc(s.HandleLogin)))
-	mux.Handle("/validate", loggingMiddleware(http.HandlerFunc(s.HandleValidateToken)))
-	mux.Handle("/status", loggingMiddleware(http.HandlerFunc(s.HandleStatus)))
+	// Log every request, including those whose handler panics
+	middleware := shared.Chain(
+		shared.LoggingMiddleware(s.logger),
+		shared.RecoveryMiddleware(s.logger),
+		shared.CORSMiddleware(s.allowedOrigins),
+	)
+
+	mux.Handle("/login", middleware(http.HandlerFunc(s.HandleLogin)))
+	mux.Handle("/validate", middleware(http.HandlerFunc(s.HandleValidateToken)))
+	mux.Handle("/status", middleware(http.HandlerFunc(s.HandleStatus)))
+
+	// Debug-only routes are never registered in production to avoid
+	// exposing an open reflector
+	if s.debug {
+		mux.Handle("/echo", middleware(http.HandlerFunc(s.HandleEcho)))
+	}
 
-	// Add CORS handling
-	mux.Handle("/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		shared.SetCORSHeaders(w)
-		if r.Method == http.MethodOptions {
-			w.WriteHeader(http.StatusOK)
-			return
-		}
+	// Unknown paths still answer CORS preflights before reporting 404
+	mux.Handle("/", middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 		shared.WriteJSONError(w, http.StatusNotFound, "Endpoint not found")
-	}))
+	})))
 
 	return mux
 }
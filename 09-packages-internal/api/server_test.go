@@ -0,0 +1,201 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go-fast/09-packages-internal/internal/config"
+	"go-fast/09-packages-internal/internal/shared"
+)
+
+func TestEchoRouteGatedOnDebug(t *testing.T) {
+	tests := []struct {
+		debug    bool
+		expected int
+	}{
+		{true, http.StatusOK},
+		{false, http.StatusNotFound},
+	}
+
+	for _, test := range tests {
+		server := NewServerWithConfig(&config.Config{Debug: test.debug})
+		server.logger = func(string, ...interface{}) {}
+		mux := server.SetupRoutes()
+
+		req := httptest.NewRequest(http.MethodPost, "/echo", strings.NewReader(`{"hello":"world"}`))
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+
+		if rec.Code != test.expected {
+			t.Errorf("POST /echo with debug=%t: status = %d; want %d", test.debug, rec.Code, test.expected)
+		}
+	}
+}
+
+func TestHandleEcho(t *testing.T) {
+	server := NewServerWithConfig(&config.Config{Debug: true})
+	server.logger = func(string, ...interface{}) {}
+
+	req := httptest.NewRequest(http.MethodPost, "/echo", strings.NewReader(`{"a":1,"b":[true,"x"]}`))
+	rec := httptest.NewRecorder()
+	server.HandleEcho(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("HandleEcho status = %d; want %d", rec.Code, http.StatusOK)
+	}
+	if got := strings.TrimSpace(rec.Body.String()); got != `{"a":1,"b":[true,"x"]}` {
+		t.Errorf("HandleEcho body = %s; want the request payload", got)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/echo", strings.NewReader(`{"a":`))
+	rec = httptest.NewRecorder()
+	server.HandleEcho(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("HandleEcho with malformed body: status = %d; want %d", rec.Code, http.StatusBadRequest)
+	}
+	if !strings.Contains(rec.Body.String(), "failed to parse JSON body") {
+		t.Errorf("HandleEcho error body = %s; want decode error details", rec.Body.String())
+	}
+}
+
+func TestWriteServerErrorDetailPolicy(t *testing.T) {
+	cause := errors.New("entropy source unavailable")
+
+	tests := []struct {
+		debug       bool
+		wantDetails bool
+	}{
+		{true, true},
+		{false, false},
+	}
+
+	for _, test := range tests {
+		server := NewServerWithConfig(&config.Config{Debug: test.debug})
+		rec := httptest.NewRecorder()
+		server.writeServerError(rec, http.StatusInternalServerError, cause)
+
+		var body shared.HTTPError
+		if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+			t.Fatalf("decoding error body: %v", err)
+		}
+		if body.Message != "internal error" {
+			t.Errorf("debug=%t: message = %q; want %q", test.debug, body.Message, "internal error")
+		}
+		if hasDetails := body.Details == cause.Error(); hasDetails != test.wantDetails {
+			t.Errorf("debug=%t: details = %q; want details included = %t", test.debug, body.Details, test.wantDetails)
+		}
+	}
+}
+
+func TestNewServerWithConfigTokenTTL(t *testing.T) {
+	server := NewServerWithConfig(&config.Config{TokenTTL: 5 * time.Minute})
+
+	token, err := server.authenticator.GenerateToken(1)
+	if err != nil {
+		t.Fatalf("GenerateToken unexpected error: %v", err)
+	}
+
+	_, expiresAt, err := server.authenticator.ValidateTokenWithInfo(token)
+	if err != nil {
+		t.Fatalf("ValidateTokenWithInfo unexpected error: %v", err)
+	}
+	if remaining := time.Until(expiresAt); remaining > 5*time.Minute || remaining < 4*time.Minute {
+		t.Errorf("token expires in %v; want about 5m", remaining)
+	}
+}
+
+func TestSetupRoutesCORS(t *testing.T) {
+	server := NewServerWithConfig(&config.Config{AllowedOrigins: []string{"https://app.example.com"}})
+	server.logger = func(string, ...interface{}) {}
+	mux := server.SetupRoutes()
+
+	tests := []struct {
+		path        string
+		origin      string
+		allowOrigin string
+	}{
+		{"/status", "https://app.example.com", "https://app.example.com"},
+		{"/status", "https://evil.example.com", ""},
+		{"/missing", "https://app.example.com", "https://app.example.com"},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest(http.MethodOptions, test.path, nil)
+		req.Header.Set("Origin", test.origin)
+		req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+
+		if got := rec.Header().Get("Access-Control-Allow-Origin"); got != test.allowOrigin {
+			t.Errorf("preflight %s from %s: Access-Control-Allow-Origin = %q; want %q",
+				test.path, test.origin, got, test.allowOrigin)
+		}
+		if rec.Code != http.StatusNoContent {
+			t.Errorf("preflight %s from %s: status = %d; want %d", test.path, test.origin, rec.Code, http.StatusNoContent)
+		}
+	}
+}
+
+func TestHandleValidateTokenAuthorization(t *testing.T) {
+	server := NewServer()
+	server.logger = func(string, ...interface{}) {}
+
+	token, err := server.authenticator.GenerateToken(1)
+	if err != nil {
+		t.Fatalf("GenerateToken unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		header string
+		status int
+	}{
+		{"Bearer " + token, http.StatusOK},
+		{"bearer " + token, http.StatusOK},
+		{"", http.StatusBadRequest},
+		{token, http.StatusUnauthorized},
+		{"Bearer not-a-token", http.StatusUnauthorized},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/validate", nil)
+		if test.header != "" {
+			req.Header.Set("Authorization", test.header)
+		}
+		rec := httptest.NewRecorder()
+		server.HandleValidateToken(rec, req)
+
+		if rec.Code != test.status {
+			t.Errorf("Authorization %q: status = %d; want %d", test.header, rec.Code, test.status)
+		}
+	}
+}
+
+func TestHandleLoginReservedUsername(t *testing.T) {
+	server := NewServer()
+	server.logger = func(string, ...interface{}) {}
+
+	// An account created before "root" was reserved must still be able to log in
+	if err := server.authenticator.RegisterUser("root", "Sup3r$ecret!"); err != nil {
+		t.Fatalf("RegisterUser unexpected error: %v", err)
+	}
+
+	body := strings.NewReader(`{"username":"root","password":"Sup3r$ecret!"}`)
+	req := httptest.NewRequest(http.MethodPost, "/login", body)
+	rec := httptest.NewRecorder()
+	server.HandleLogin(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("POST /login as %q: status = %d; want %d (body %s)", "root", rec.Code, http.StatusOK, rec.Body)
+	}
+
+	var response LoginResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil || response.Token == "" {
+		t.Errorf("login response = %s (%v); want a token", rec.Body, err)
+	}
+}
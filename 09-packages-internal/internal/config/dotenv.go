@@ -0,0 +1,74 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// LoadFromFile reads configuration from a .env file of KEY=VALUE lines,
+// applying the same parsing and validation as Load. Variables already set
+// in the environment take precedence, so they need not appear in the file.
+func LoadFromFile(path string) (*Config, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open config file: %w", err)
+	}
+	defer file.Close()
+
+	values, err := parseDotEnv(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	return load(func(key string) string {
+		if value := os.Getenv(key); value != "" {
+			return value
+		}
+		return values[key]
+	})
+}
+
+// parseDotEnv reads KEY=VALUE pairs, one per line. Blank lines and lines
+// starting with '#' are ignored, an optional "export " prefix is allowed,
+// and values may be wrapped in single or double quotes.
+func parseDotEnv(r io.Reader) (map[string]string, error) {
+	values := make(map[string]string)
+
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		line = strings.TrimPrefix(line, "export ")
+		key, value, found := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !found || key == "" {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE, got %q", lineNumber, line)
+		}
+
+		values[key] = unquote(strings.TrimSpace(value))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+// unquote strips one pair of matching single or double quotes around value.
+func unquote(value string) string {
+	if len(value) >= 2 {
+		first, last := value[0], value[len(value)-1]
+		if first == last && (first == '"' || first == '\'') {
+			return value[1 : len(value)-1]
+		}
+	}
+	return value
+}
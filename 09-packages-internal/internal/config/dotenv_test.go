@@ -0,0 +1,105 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// clearEnv unsets every variable the loaders read for the duration of the test.
+func clearEnv(t *testing.T) {
+	t.Helper()
+	keys := []string{"DATABASE_URL", "API_KEY", "DEBUG", "PORT", "MAX_RETRIES", "TOKEN_TTL", "ALLOWED_ORIGINS"}
+	for _, key := range keys {
+		t.Setenv(key, "")
+	}
+}
+
+// writeEnvFile writes contents to a .env file in a temporary directory.
+func writeEnvFile(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), ".env")
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatalf("writing %s: %v", path, err)
+	}
+	return path
+}
+
+func TestLoadFromFile(t *testing.T) {
+	clearEnv(t)
+	path := writeEnvFile(t, `# Local development settings
+DATABASE_URL=postgres://db.local:5432/app
+
+export API_KEY="file-api-key"
+PORT = 3000
+DEBUG=true
+MAX_RETRIES='5'
+`)
+
+	cfg, err := LoadFromFile(path)
+	if err != nil {
+		t.Fatalf("LoadFromFile unexpected error: %v", err)
+	}
+
+	if cfg.DatabaseURL != "postgres://db.local:5432/app" {
+		t.Errorf("DatabaseURL = %q; want %q", cfg.DatabaseURL, "postgres://db.local:5432/app")
+	}
+	if cfg.APIKey != "file-api-key" {
+		t.Errorf("APIKey = %q; want %q", cfg.APIKey, "file-api-key")
+	}
+	if cfg.Port != 3000 {
+		t.Errorf("Port = %d; want 3000", cfg.Port)
+	}
+	if !cfg.Debug {
+		t.Error("Debug = false; want true")
+	}
+	if cfg.MaxRetries != 5 {
+		t.Errorf("MaxRetries = %d; want 5", cfg.MaxRetries)
+	}
+}
+
+func TestLoadFromFileEnvironmentTakesPrecedence(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("API_KEY", "env-api-key")
+	path := writeEnvFile(t, "PORT=4000\n")
+
+	cfg, err := LoadFromFile(path)
+	if err != nil {
+		t.Fatalf("LoadFromFile unexpected error: %v", err)
+	}
+
+	if cfg.APIKey != "env-api-key" {
+		t.Errorf("APIKey = %q; want %q", cfg.APIKey, "env-api-key")
+	}
+	if cfg.Port != 4000 {
+		t.Errorf("Port = %d; want 4000", cfg.Port)
+	}
+	if cfg.DatabaseURL != "localhost:5432" {
+		t.Errorf("DatabaseURL = %q; want default %q", cfg.DatabaseURL, "localhost:5432")
+	}
+}
+
+func TestLoadFromFileErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+	}{
+		{"missing API key", "PORT=3000\n"},
+		{"invalid port", "API_KEY=key\nPORT=abc\n"},
+		{"zero port", "API_KEY=key\nPORT=0\n"},
+		{"port out of range", "API_KEY=key\nPORT=99999\n"},
+		{"malformed line", "API_KEY=key\nPORT\n"},
+	}
+
+	for _, test := range tests {
+		clearEnv(t)
+		path := writeEnvFile(t, test.contents)
+		if _, err := LoadFromFile(path); err == nil {
+			t.Errorf("LoadFromFile (%s) expected error but got none", test.name)
+		}
+	}
+
+	if _, err := LoadFromFile(filepath.Join(t.TempDir(), "missing.env")); err == nil {
+		t.Error("LoadFromFile with missing file expected error but got none")
+	}
+}
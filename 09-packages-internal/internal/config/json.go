@@ -0,0 +1,84 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// LoadJSON reads configuration from a JSON document. Fields that are absent
+// keep the same defaults as Load, and the result is validated the same way.
+// Unknown fields are rejected to catch typos.
+func LoadJSON(r io.Reader) (*Config, error) {
+	cfg := &Config{
+		DatabaseURL: "localhost:5432",
+		Port:        8080,
+		MaxRetries:  3,
+		TokenTTL:    time.Hour,
+	}
+
+	if err := json.NewDecoder(r).Decode(cfg); err != nil {
+		return nil, fmt.Errorf("failed to decode JSON config: %w", err)
+	}
+
+	cfg.defaultAllowedOrigins()
+
+	if err := cfg.validate(jsonName); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// UnmarshalJSON decodes a Config, reading token_ttl as a duration string
+// such as "30m" rather than a count of nanoseconds. Unknown fields are
+// rejected.
+func (c *Config) UnmarshalJSON(data []byte) error {
+	// plain has Config's fields but not this method, avoiding recursion
+	type plain Config
+	aux := struct {
+		*plain
+		TokenTTL *string `json:"token_ttl"`
+	}{plain: (*plain)(c)}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&aux); err != nil {
+		return err
+	}
+
+	if aux.TokenTTL != nil {
+		ttl, err := time.ParseDuration(*aux.TokenTTL)
+		if err != nil {
+			return fmt.Errorf("invalid token_ttl value %q: %w", *aux.TokenTTL, err)
+		}
+		c.TokenTTL = ttl
+	}
+
+	return nil
+}
+
+// validate checks the invariants every loaded Config must satisfy. name
+// maps a Config field name to how the loader's source refers to it, such as
+// jsonName or envName, so errors point at the setting the user wrote.
+func (c *Config) validate(name func(field string) string) error {
+	if c.APIKey == "" {
+		return fmt.Errorf("%s is required", name("APIKey"))
+	}
+
+	if c.MaxRetries < 1 {
+		return fmt.Errorf("%s must be at least 1, got %d", name("MaxRetries"), c.MaxRetries)
+	}
+
+	if c.TokenTTL <= 0 {
+		return fmt.Errorf("%s must be positive, got %v", name("TokenTTL"), c.TokenTTL)
+	}
+
+	if c.Port < 1 || c.Port > 65535 {
+		return fmt.Errorf("%s must be between 1 and 65535, got %d", name("Port"), c.Port)
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadJSON(t *testing.T) {
+	input := `{
+		"database_url": "postgres://db.local:5432/app",
+		"api_key": "json-api-key",
+		"port": 9090,
+		"debug": true,
+		"max_retries": 7,
+		"token_ttl": "30m",
+		"allowed_origins": ["https://example.com"]
+	}`
+
+	cfg, err := LoadJSON(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("LoadJSON unexpected error: %v", err)
+	}
+
+	want := Config{
+		DatabaseURL: "postgres://db.local:5432/app",
+		APIKey:      "json-api-key",
+		Port:        9090,
+		Debug:       true,
+		MaxRetries:  7,
+		TokenTTL:    30 * time.Minute,
+
+		AllowedOrigins: []string{"https://example.com"},
+	}
+	if !reflect.DeepEqual(*cfg, want) {
+		t.Errorf("LoadJSON = %+v; want %+v", *cfg, want)
+	}
+}
+
+func TestLoadJSONDefaults(t *testing.T) {
+	cfg, err := LoadJSON(strings.NewReader(`{"api_key": "json-api-key"}`))
+	if err != nil {
+		t.Fatalf("LoadJSON unexpected error: %v", err)
+	}
+
+	if cfg.DatabaseURL != "localhost:5432" || cfg.Port != 8080 || cfg.MaxRetries != 3 || cfg.Debug ||
+		cfg.TokenTTL != time.Hour || !reflect.DeepEqual(cfg.AllowedOrigins, []string{"*"}) {
+		t.Errorf("LoadJSON = %+v; want defaults for every field but APIKey", *cfg)
+	}
+}
+
+func TestLoadJSONErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"missing api_key", `{"port": 8080}`},
+		{"zero retries", `{"api_key": "key", "max_retries": 0}`},
+		{"negative port", `{"api_key": "key", "port": -1}`},
+		{"port as string", `{"api_key": "key", "port": "8080"}`},
+		{"malformed token_ttl", `{"api_key": "key", "token_ttl": "soon"}`},
+		{"numeric token_ttl", `{"api_key": "key", "token_ttl": 3600}`},
+		{"unknown field", `{"api_key": "key", "prot": 8080}`},
+		{"malformed", `{"api_key": "key",`},
+		{"empty", ``},
+	}
+
+	for _, test := range tests {
+		if _, err := LoadJSON(strings.NewReader(test.input)); err == nil {
+			t.Errorf("LoadJSON (%s) expected error but got none", test.name)
+		}
+	}
+
+	// Validation errors use the JSON keys the document was written with
+	messages := map[string]string{
+		`{"port": 8080}`:                       "api_key is required",
+		`{"api_key": "key", "max_retries": 0}`: "max_retries must be at least 1, got 0",
+	}
+	for input, want := range messages {
+		if _, err := LoadJSON(strings.NewReader(input)); err == nil || err.Error() != want {
+			t.Errorf("LoadJSON(%s) error = %v; want %q", input, err, want)
+		}
+	}
+}
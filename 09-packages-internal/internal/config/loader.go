@@ -4,30 +4,76 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 )
 
 // Config holds application-wide configuration.
 // This is internal to prevent external packages from depending on config structure.
+// The env, default and required tags drive LoadWithTags.
 type Config struct {
-	DatabaseURL string
-	APIKey      string
-	Port        int
-	Debug       bool
-	MaxRetries  int
+	DatabaseURL string `json:"database_url" env:"DATABASE_URL" default:"localhost:5432"`
+	APIKey      string `json:"api_key" env:"API_KEY" required:"true"`
+	Port        int    `json:"port" env:"PORT" default:"8080"`
+	Debug       bool   `json:"debug" env:"DEBUG" default:"false"`
+	MaxRetries  int    `json:"max_retries" env:"MAX_RETRIES" default:"3"`
+
+	// TokenTTL is how long issued auth tokens stay valid, written as a
+	// duration string such as "30m" or "1h" in both env and JSON.
+	TokenTTL time.Duration `json:"token_ttl" env:"TOKEN_TTL" default:"1h"`
+
+	// AllowedOrigins lists the origins permitted for cross-origin requests,
+	// given as a comma-separated list in env. It defaults to ["*"].
+	AllowedOrigins []string `json:"allowed_origins" env:"ALLOWED_ORIGINS" default:"*"`
 }
 
 // Load reads configuration from environment variables.
 // This function is only available to packages within this module.
 func Load() (*Config, error) {
+	return load(os.Getenv)
+}
+
+// LoadWithPrefix reads configuration like Load, but from variables named
+// with the given prefix, such as MYAPP_PORT for prefix "MYAPP". Any variable
+// whose prefixed form is unset falls back to the unprefixed name.
+func LoadWithPrefix(prefix string) (*Config, error) {
+	prefix = strings.TrimSuffix(prefix, "_")
+	if prefix == "" {
+		return Load()
+	}
+
+	return load(func(key string) string {
+		if value := os.Getenv(prefix + "_" + key); value != "" {
+			return value
+		}
+		return os.Getenv(key)
+	})
+}
+
+// load builds a Config from the values returned by getenv, which must
+// return "" for unset keys. It holds the parsing shared by the env-based
+// loaders; validate then applies the same rules as every other loader.
+func load(getenv func(string) string) (*Config, error) {
 	cfg := &Config{
-		DatabaseURL: getEnvOrDefault("DATABASE_URL", "localhost:5432"),
-		APIKey:      os.Getenv("API_KEY"),
-		Debug:       os.Getenv("DEBUG") == "true",
+		DatabaseURL: getOrDefault(getenv, "DATABASE_URL", "localhost:5432"),
+		APIKey:      getenv("API_KEY"),
+		Debug:       getenv("DEBUG") == "true",
 		MaxRetries:  3, // default value
 	}
 
+	cfg.AllowedOrigins = splitList(getenv("ALLOWED_ORIGINS"))
+	cfg.defaultAllowedOrigins()
+
+	// Parse token TTL, defaulting to one hour
+	ttlStr := getOrDefault(getenv, "TOKEN_TTL", "1h")
+	ttl, err := time.ParseDuration(ttlStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TOKEN_TTL value %q: %w", ttlStr, err)
+	}
+	cfg.TokenTTL = ttl
+
 	// Parse port from environment
-	if portStr := os.Getenv("PORT"); portStr != "" {
+	if portStr := getenv("PORT"); portStr != "" {
 		port, err := strconv.Atoi(portStr)
 		if err != nil {
 			return nil, fmt.Errorf("invalid PORT value %q: %w", portStr, err)
@@ -38,37 +84,52 @@ func Load() (*Config, error) {
 	}
 
 	// Parse max retries if provided
-	if retriesStr := os.Getenv("MAX_RETRIES"); retriesStr != "" {
+	if retriesStr := getenv("MAX_RETRIES"); retriesStr != "" {
 		retries, err := strconv.Atoi(retriesStr)
 		if err != nil {
 			return nil, fmt.Errorf("invalid MAX_RETRIES value %q: %w", retriesStr, err)
 		}
-		if retries < 1 {
-			return nil, fmt.Errorf("MAX_RETRIES must be at least 1, got %d", retries)
-		}
 		cfg.MaxRetries = retries
 	}
 
-	// Validate required fields
-	if cfg.APIKey == "" {
-		return nil, fmt.Errorf("API_KEY environment variable is required")
+	if err := cfg.validate(envName); err != nil {
+		return nil, err
 	}
 
 	return cfg, nil
 }
 
-// getEnvOrDefault returns the environment variable value or a default if not set.
-func getEnvOrDefault(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
+// getOrDefault returns the value getenv reports for key, or a default if not set.
+func getOrDefault(getenv func(string) string, key, defaultValue string) string {
+	if value := getenv(key); value != "" {
 		return value
 	}
 	return defaultValue
 }
 
+// defaultAllowedOrigins allows every origin when none are configured.
+func (c *Config) defaultAllowedOrigins() {
+	if len(c.AllowedOrigins) == 0 {
+		c.AllowedOrigins = []string{"*"}
+	}
+}
+
+// splitList splits a comma-separated value, trimming whitespace around each
+// entry and dropping empty ones.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 // String returns a string representation of the config (without sensitive data).
 func (c *Config) String() string {
-	return fmt.Sprintf("Config{DatabaseURL: %s, Port: %d, Debug: %t, MaxRetries: %d}",
-		c.DatabaseURL, c.Port, c.Debug, c.MaxRetries)
+	return fmt.Sprintf("Config{DatabaseURL: %s, Port: %d, Debug: %t, MaxRetries: %d, TokenTTL: %v}",
+		c.DatabaseURL, c.Port, c.Debug, c.MaxRetries, c.TokenTTL)
 }
 
 // IsProduction returns true if the application is running in production mode.
@@ -0,0 +1,149 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLoadTokenTTL(t *testing.T) {
+	tests := []struct {
+		value    string
+		want     time.Duration
+		hasError bool
+	}{
+		{"", time.Hour, false},
+		{"30m", 30 * time.Minute, false},
+		{"1h", time.Hour, false},
+		{"1h30m", 90 * time.Minute, false},
+		{"45s", 45 * time.Second, false},
+		{"soon", 0, true},
+		{"30", 0, true},
+		{"-5m", 0, true},
+		{"0s", 0, true},
+	}
+
+	for _, test := range tests {
+		clearEnv(t)
+		t.Setenv("API_KEY", "key")
+		t.Setenv("TOKEN_TTL", test.value)
+
+		cfg, err := Load()
+		if test.hasError {
+			if err == nil {
+				t.Errorf("Load with TOKEN_TTL=%q expected error but got none", test.value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Load with TOKEN_TTL=%q unexpected error: %v", test.value, err)
+			continue
+		}
+		if cfg.TokenTTL != test.want {
+			t.Errorf("Load with TOKEN_TTL=%q: TokenTTL = %v; want %v", test.value, cfg.TokenTTL, test.want)
+		}
+	}
+}
+
+func TestLoadValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		hasError bool
+		message  string // Expected error message, when hasError is set
+	}{
+		{"valid", map[string]string{"API_KEY": "key", "PORT": "443"}, false, ""},
+		{"highest port", map[string]string{"API_KEY": "key", "PORT": "65535"}, false, ""},
+		{"missing API key", map[string]string{"PORT": "443"}, true,
+			"API_KEY environment variable is required"},
+		{"zero port", map[string]string{"API_KEY": "key", "PORT": "0"}, true,
+			"PORT environment variable must be between 1 and 65535, got 0"},
+		{"port too large", map[string]string{"API_KEY": "key", "PORT": "99999"}, true,
+			"PORT environment variable must be between 1 and 65535, got 99999"},
+		{"negative port", map[string]string{"API_KEY": "key", "PORT": "-1"}, true,
+			"PORT environment variable must be between 1 and 65535, got -1"},
+		{"zero retries", map[string]string{"API_KEY": "key", "MAX_RETRIES": "0"}, true,
+			"MAX_RETRIES environment variable must be at least 1, got 0"},
+		{"zero token TTL", map[string]string{"API_KEY": "key", "TOKEN_TTL": "0s"}, true,
+			"TOKEN_TTL environment variable must be positive, got 0s"},
+	}
+
+	for _, test := range tests {
+		clearEnv(t)
+		for key, value := range test.env {
+			t.Setenv(key, value)
+		}
+
+		_, err := Load()
+		if test.hasError && err == nil {
+			t.Errorf("Load (%s) expected error but got none", test.name)
+		}
+		if test.hasError && err != nil && err.Error() != test.message {
+			t.Errorf("Load (%s) error = %q; want %q", test.name, err, test.message)
+		}
+		if !test.hasError && err != nil {
+			t.Errorf("Load (%s) unexpected error: %v", test.name, err)
+		}
+	}
+}
+
+func TestLoadWithPrefix(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("API_KEY", "plain-api-key")
+	t.Setenv("PORT", "8000")
+	t.Setenv("MAX_RETRIES", "2")
+	t.Setenv("MYAPP_PORT", "9000")
+	t.Setenv("MYAPP_API_KEY", "prefixed-api-key")
+
+	for _, prefix := range []string{"MYAPP", "MYAPP_"} {
+		cfg, err := LoadWithPrefix(prefix)
+		if err != nil {
+			t.Fatalf("LoadWithPrefix(%q) unexpected error: %v", prefix, err)
+		}
+
+		if cfg.Port != 9000 {
+			t.Errorf("LoadWithPrefix(%q): Port = %d; want prefixed value 9000", prefix, cfg.Port)
+		}
+		if cfg.APIKey != "prefixed-api-key" {
+			t.Errorf("LoadWithPrefix(%q): APIKey = %q; want prefixed value", prefix, cfg.APIKey)
+		}
+		if cfg.MaxRetries != 2 {
+			t.Errorf("LoadWithPrefix(%q): MaxRetries = %d; want unprefixed fallback 2", prefix, cfg.MaxRetries)
+		}
+	}
+
+	t.Setenv("MYAPP_PORT", "not-a-port")
+	if _, err := LoadWithPrefix("MYAPP"); err == nil {
+		t.Error("LoadWithPrefix with invalid MYAPP_PORT expected error but got none")
+	}
+}
+
+func TestLoadAllowedOrigins(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []string
+	}{
+		{"", []string{"*"}},
+		{"https://example.com", []string{"https://example.com"}},
+		{"https://a.example, https://b.example ,https://c.example", []string{
+			"https://a.example", "https://b.example", "https://c.example",
+		}},
+		{"https://a.example,,  ,https://b.example,", []string{"https://a.example", "https://b.example"}},
+		{" , ", []string{"*"}},
+	}
+
+	for _, test := range tests {
+		clearEnv(t)
+		t.Setenv("API_KEY", "key")
+		t.Setenv("ALLOWED_ORIGINS", test.value)
+
+		cfg, err := Load()
+		if err != nil {
+			t.Fatalf("Load with ALLOWED_ORIGINS=%q unexpected error: %v", test.value, err)
+		}
+		if !reflect.DeepEqual(cfg.AllowedOrigins, test.want) {
+			t.Errorf("Load with ALLOWED_ORIGINS=%q: AllowedOrigins = %q; want %q",
+				test.value, cfg.AllowedOrigins, test.want)
+		}
+	}
+}
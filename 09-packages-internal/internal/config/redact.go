@@ -0,0 +1,71 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Redacted returns a representation of the config that is safe to log. Unlike
+// String it includes the API key, partially masked, and the database URL with
+// any password removed.
+func (c *Config) Redacted() string {
+	return fmt.Sprintf("Config{DatabaseURL: %s, APIKey: %s, Port: %d, Debug: %t, MaxRetries: %d, TokenTTL: %v}",
+		redactURLPassword(c.DatabaseURL), maskSecret(c.APIKey), c.Port, c.Debug, c.MaxRetries, c.TokenTTL)
+}
+
+// maskSecret hides the middle of a secret, keeping enough of each end to
+// tell keys apart: four characters for secrets of 16 or more, two for
+// secrets of 8 or more, and none for anything shorter.
+func maskSecret(secret string) string {
+	const mask = "****"
+
+	switch {
+	case len(secret) >= 16:
+		return secret[:4] + mask + secret[len(secret)-4:]
+	case len(secret) >= 8:
+		return secret[:2] + mask + secret[len(secret)-2:]
+	default:
+		return mask
+	}
+}
+
+// redactURLPassword removes the password from a URL's user info, leaving
+// the user name. Values without user info are returned as is. It fails
+// closed: if the value does not parse as a URL with user info, for example
+// because the password contains '#' or a bad escape, the password is cut
+// out by hand instead.
+func redactURLPassword(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.User == nil {
+		return redactUserInfo(raw)
+	}
+
+	if _, hasPassword := u.User.Password(); !hasPassword {
+		return raw
+	}
+
+	u.User = url.User(u.User.Username())
+	return u.String()
+}
+
+// redactUserInfo drops the password from the text between "://" (or the
+// start of raw) and the last '@', without interpreting the rest of raw.
+func redactUserInfo(raw string) string {
+	start := 0
+	if i := strings.Index(raw, "://"); i >= 0 {
+		start = i + len("://")
+	}
+
+	at := strings.LastIndex(raw[start:], "@")
+	if at < 0 {
+		return raw
+	}
+	at += start
+
+	user, _, hasPassword := strings.Cut(raw[start:at], ":")
+	if !hasPassword {
+		return raw
+	}
+	return raw[:start] + user + raw[at:]
+}
@@ -0,0 +1,124 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// LoadWithTags reads configuration from environment variables named by the
+// env tags on Config. Unset variables fall back to the default tag, and a
+// field tagged required:"true" must end up non-empty. Adding a setting only
+// requires adding a tagged field.
+func LoadWithTags() (*Config, error) {
+	cfg := &Config{}
+	if err := loadTagged(os.Getenv, cfg); err != nil {
+		return nil, err
+	}
+	cfg.defaultAllowedOrigins()
+
+	if err := cfg.validate(envName); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// loadTagged fills the env-tagged fields of the struct that dst points to
+// with values from getenv, which must return "" for unset keys.
+func loadTagged(getenv func(string) string, dst interface{}) error {
+	value := reflect.ValueOf(dst)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("config target must be a pointer to a struct, got %T", dst)
+	}
+
+	value = value.Elem()
+	structType := value.Type()
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		key, tagged := field.Tag.Lookup("env")
+		if !tagged || !field.IsExported() {
+			continue
+		}
+
+		raw := getenv(key)
+		if raw == "" {
+			raw = field.Tag.Get("default")
+		}
+
+		if raw == "" {
+			if field.Tag.Get("required") == "true" {
+				return fmt.Errorf("%s environment variable is required", key)
+			}
+			continue
+		}
+
+		if err := setField(value.Field(i), raw); err != nil {
+			return fmt.Errorf("invalid %s value %q: %w", key, raw, err)
+		}
+	}
+
+	return nil
+}
+
+// durationType is checked before the int64 kind it shares.
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// setField parses raw according to the field's type and stores it.
+// Durations use time.ParseDuration syntax, such as "30m", and string slices
+// are comma-separated.
+func setField(field reflect.Value, raw string) error {
+	if field.Type() == durationType {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(d))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(raw)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Slice:
+		if field.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported field type %s", field.Type())
+		}
+		field.Set(reflect.ValueOf(splitList(raw)))
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+	return nil
+}
+
+// jsonName returns the JSON key of the named Config field.
+func jsonName(field string) string {
+	return configTag(field, "json")
+}
+
+// envName describes the environment variable behind the named Config field,
+// in the same words loadTagged uses for a missing required variable.
+func envName(field string) string {
+	return configTag(field, "env") + " environment variable"
+}
+
+// configTag returns the value of the key tag on the named Config field.
+func configTag(field, key string) string {
+	structField, _ := reflect.TypeOf(Config{}).FieldByName(field)
+	return structField.Tag.Get(key)
+}
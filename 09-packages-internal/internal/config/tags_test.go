@@ -0,0 +1,86 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+// taggedSettings stands in for a Config that has grown new tagged fields.
+type taggedSettings struct {
+	Name     string `env:"APP_NAME" default:"demo"`
+	Workers  int    `env:"APP_WORKERS" required:"true"`
+	Verbose  bool   `env:"APP_VERBOSE"`
+	Untagged string
+}
+
+// mapEnv returns a getenv function backed by values.
+func mapEnv(values map[string]string) func(string) string {
+	return func(key string) string {
+		return values[key]
+	}
+}
+
+func TestLoadTagged(t *testing.T) {
+	var settings taggedSettings
+	err := loadTagged(mapEnv(map[string]string{
+		"APP_WORKERS": "4",
+		"APP_VERBOSE": "true",
+		"Untagged":    "ignored",
+	}), &settings)
+	if err != nil {
+		t.Fatalf("loadTagged unexpected error: %v", err)
+	}
+
+	want := taggedSettings{Name: "demo", Workers: 4, Verbose: true}
+	if settings != want {
+		t.Errorf("loadTagged = %+v; want %+v", settings, want)
+	}
+}
+
+func TestLoadTaggedErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+	}{
+		{"missing required field", map[string]string{"APP_NAME": "svc"}},
+		{"invalid int", map[string]string{"APP_WORKERS": "many"}},
+		{"invalid bool", map[string]string{"APP_WORKERS": "4", "APP_VERBOSE": "sometimes"}},
+	}
+
+	for _, test := range tests {
+		var settings taggedSettings
+		if err := loadTagged(mapEnv(test.env), &settings); err == nil {
+			t.Errorf("loadTagged (%s) expected error but got none", test.name)
+		}
+	}
+
+	if err := loadTagged(mapEnv(nil), taggedSettings{}); err == nil {
+		t.Error("loadTagged with non-pointer target expected error but got none")
+	}
+}
+
+func TestLoadWithTagsMatchesLoad(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("API_KEY", "tagged-api-key")
+	t.Setenv("PORT", "3000")
+	t.Setenv("DEBUG", "true")
+	t.Setenv("ALLOWED_ORIGINS", "https://a.example, https://b.example")
+
+	fromTags, err := LoadWithTags()
+	if err != nil {
+		t.Fatalf("LoadWithTags unexpected error: %v", err)
+	}
+	fromLoad, err := Load()
+	if err != nil {
+		t.Fatalf("Load unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(*fromTags, *fromLoad) {
+		t.Errorf("LoadWithTags = %+v; want %+v", *fromTags, *fromLoad)
+	}
+
+	t.Setenv("API_KEY", "")
+	if _, err := LoadWithTags(); err == nil {
+		t.Error("LoadWithTags without API_KEY expected error but got none")
+	}
+}
@@ -0,0 +1,43 @@
+package shared
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// Bearer token parsing errors.
+var (
+	ErrMissingAuthorization = errors.New("authorization header missing")
+	ErrInvalidAuthScheme    = errors.New("authorization scheme must be Bearer")
+	ErrEmptyBearerToken     = errors.New("bearer token is empty")
+	ErrMalformedBearerToken = errors.New("bearer token contains whitespace")
+)
+
+// ParseBearerToken extracts the token from an "Authorization: Bearer <token>"
+// header. The scheme is matched case-insensitively, may be separated from the
+// token by spaces or tabs, and surrounding whitespace is ignored.
+func ParseBearerToken(r *http.Request) (string, error) {
+	header := strings.TrimSpace(r.Header.Get("Authorization"))
+	if header == "" {
+		return "", ErrMissingAuthorization
+	}
+
+	scheme, token := header, ""
+	if i := strings.IndexAny(header, " \t"); i >= 0 {
+		scheme, token = header[:i], header[i+1:]
+	}
+	if !strings.EqualFold(scheme, "Bearer") {
+		return "", ErrInvalidAuthScheme
+	}
+
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", ErrEmptyBearerToken
+	}
+	if strings.ContainsAny(token, " \t") {
+		return "", ErrMalformedBearerToken
+	}
+
+	return token, nil
+}
@@ -0,0 +1,48 @@
+package shared
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseBearerToken(t *testing.T) {
+	tests := []struct {
+		header string
+		token  string
+		err    error
+	}{
+		{"Bearer xyz", "xyz", nil},
+		{"bearer xyz", "xyz", nil},
+		{"BEARER xyz", "xyz", nil},
+		{"  Bearer    xyz  ", "xyz", nil},
+		{"", "", ErrMissingAuthorization},
+		{"   ", "", ErrMissingAuthorization},
+		{"xyz", "", ErrInvalidAuthScheme},
+		{"Basic dXNlcjpwYXNz", "", ErrInvalidAuthScheme},
+		{"Bearer", "", ErrEmptyBearerToken},
+		{"Bearer   ", "", ErrEmptyBearerToken},
+		{"Bearer\txyz", "xyz", nil},
+		{"Bearer \t xyz", "xyz", nil},
+		{"Bearer\t", "", ErrEmptyBearerToken},
+		{"Bearer abc def", "", ErrMalformedBearerToken},
+		{"Bearer abc\tdef", "", ErrMalformedBearerToken},
+		{"Basic\tdXNlcjpwYXNz", "", ErrInvalidAuthScheme},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if test.header != "" {
+			req.Header.Set("Authorization", test.header)
+		}
+
+		token, err := ParseBearerToken(req)
+		if !errors.Is(err, test.err) {
+			t.Errorf("ParseBearerToken(%q) error = %v; want %v", test.header, err, test.err)
+		}
+		if token != test.token {
+			t.Errorf("ParseBearerToken(%q) = %q; want %q", test.header, token, test.token)
+		}
+	}
+}
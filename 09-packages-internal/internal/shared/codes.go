@@ -0,0 +1,60 @@
+package shared
+
+import "net/http"
+
+// ErrorCode identifies a category of API error. Each code maps to an HTTP
+// status and a default message, keeping that mapping in one place.
+type ErrorCode string
+
+// Error codes understood by WriteCodedError.
+const (
+	CodeBadRequest       ErrorCode = "bad_request"
+	CodeValidation       ErrorCode = "validation_failed"
+	CodeUnauthorized     ErrorCode = "unauthorized"
+	CodeForbidden        ErrorCode = "forbidden"
+	CodeNotFound         ErrorCode = "not_found"
+	CodeMethodNotAllowed ErrorCode = "method_not_allowed"
+	CodeRateLimited      ErrorCode = "rate_limited"
+	CodeInternal         ErrorCode = "internal"
+	CodeUnavailable      ErrorCode = "unavailable"
+)
+
+// errorCodeInfo is the status and default message for an ErrorCode.
+type errorCodeInfo struct {
+	status  int
+	message string
+}
+
+var errorCodes = map[ErrorCode]errorCodeInfo{
+	CodeBadRequest:       {http.StatusBadRequest, "Bad request"},
+	CodeValidation:       {http.StatusBadRequest, "Validation failed"},
+	CodeUnauthorized:     {http.StatusUnauthorized, "Unauthorized"},
+	CodeForbidden:        {http.StatusForbidden, "Forbidden"},
+	CodeNotFound:         {http.StatusNotFound, "Not found"},
+	CodeMethodNotAllowed: {http.StatusMethodNotAllowed, "Method not allowed"},
+	CodeRateLimited:      {http.StatusTooManyRequests, "Rate limit exceeded"},
+	CodeInternal:         {http.StatusInternalServerError, "internal error"},
+	CodeUnavailable:      {http.StatusServiceUnavailable, "Service unavailable"},
+}
+
+// HTTPStatus returns the HTTP status for the code. Unknown codes map to 500.
+func (c ErrorCode) HTTPStatus() int {
+	if info, ok := errorCodes[c]; ok {
+		return info.status
+	}
+	return http.StatusInternalServerError
+}
+
+// Message returns the code's default client-facing message.
+func (c ErrorCode) Message() string {
+	if info, ok := errorCodes[c]; ok {
+		return info.message
+	}
+	return errorCodes[CodeInternal].message
+}
+
+// WriteCodedError writes a JSON error response using the code's status and
+// default message.
+func WriteCodedError(w http.ResponseWriter, code ErrorCode) {
+	WriteJSONError(w, code.HTTPStatus(), code.Message())
+}
@@ -0,0 +1,52 @@
+package shared
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestErrorCodeHTTPStatus(t *testing.T) {
+	tests := []struct {
+		code   ErrorCode
+		status int
+	}{
+		{CodeBadRequest, http.StatusBadRequest},
+		{CodeValidation, http.StatusBadRequest},
+		{CodeUnauthorized, http.StatusUnauthorized},
+		{CodeForbidden, http.StatusForbidden},
+		{CodeNotFound, http.StatusNotFound},
+		{CodeMethodNotAllowed, http.StatusMethodNotAllowed},
+		{CodeRateLimited, http.StatusTooManyRequests},
+		{CodeInternal, http.StatusInternalServerError},
+		{CodeUnavailable, http.StatusServiceUnavailable},
+		{ErrorCode("made_up"), http.StatusInternalServerError},
+	}
+
+	for _, test := range tests {
+		if status := test.code.HTTPStatus(); status != test.status {
+			t.Errorf("%q.HTTPStatus() = %d; want %d", test.code, status, test.status)
+		}
+		if test.code.Message() == "" {
+			t.Errorf("%q.Message() is empty", test.code)
+		}
+	}
+}
+
+func TestWriteCodedError(t *testing.T) {
+	rec := httptest.NewRecorder()
+	WriteCodedError(rec, CodeUnauthorized)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d; want %d", rec.Code, http.StatusUnauthorized)
+	}
+
+	var body HTTPError
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("decoding response body: %v", err)
+	}
+	if body.Code != http.StatusUnauthorized || body.Message != CodeUnauthorized.Message() {
+		t.Errorf("body = %+v; want code 401 and message %q", body, CodeUnauthorized.Message())
+	}
+}
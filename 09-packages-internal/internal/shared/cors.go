@@ -0,0 +1,42 @@
+package shared
+
+import (
+	"net/http"
+)
+
+// CORSMiddleware creates a middleware that allows cross-origin requests only
+// from allowedOrigins. The request's Origin is echoed back when it is on the
+// list; otherwise no CORS headers are sent and the browser blocks the
+// response. An entry of "*" allows any origin. Preflight OPTIONS requests
+// are answered directly with 204 No Content.
+func CORSMiddleware(allowedOrigins []string) func(http.Handler) http.Handler {
+	allowed := make(map[string]bool, len(allowedOrigins))
+	for _, origin := range allowedOrigins {
+		allowed[origin] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			w.Header().Add("Vary", "Origin")
+
+			originAllowed := origin != "" && (allowed["*"] || allowed[origin])
+			if originAllowed {
+				w.Header().Set("Access-Control-Allow-Origin", origin)
+			}
+
+			isPreflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+			if !isPreflight {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if originAllowed {
+				w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
+				w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+				w.Header().Set("Access-Control-Max-Age", "86400") // 24 hours
+			}
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+}
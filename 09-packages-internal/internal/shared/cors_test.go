@@ -0,0 +1,75 @@
+package shared
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCORSMiddleware(t *testing.T) {
+	handlerCalled := false
+	handler := CORSMiddleware([]string{"https://app.example.com"})(
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			handlerCalled = true
+			w.WriteHeader(http.StatusOK)
+		}),
+	)
+
+	tests := []struct {
+		name        string
+		method      string
+		origin      string
+		preflight   bool
+		allowOrigin string
+		status      int
+		reachesNext bool
+	}{
+		{"allowed origin", http.MethodGet, "https://app.example.com", false, "https://app.example.com", 200, true},
+		{"disallowed origin", http.MethodGet, "https://evil.example.com", false, "", 200, true},
+		{"no origin", http.MethodGet, "", false, "", 200, true},
+		{"allowed preflight", http.MethodOptions, "https://app.example.com", true, "https://app.example.com", 204, false},
+		{"disallowed preflight", http.MethodOptions, "https://evil.example.com", true, "", 204, false},
+		{"plain OPTIONS", http.MethodOptions, "https://app.example.com", false, "https://app.example.com", 200, true},
+	}
+
+	for _, test := range tests {
+		handlerCalled = false
+		req := httptest.NewRequest(test.method, "/login", nil)
+		if test.origin != "" {
+			req.Header.Set("Origin", test.origin)
+		}
+		if test.preflight {
+			req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if got := rec.Header().Get("Access-Control-Allow-Origin"); got != test.allowOrigin {
+			t.Errorf("%s: Access-Control-Allow-Origin = %q; want %q", test.name, got, test.allowOrigin)
+		}
+		if rec.Code != test.status {
+			t.Errorf("%s: status = %d; want %d", test.name, rec.Code, test.status)
+		}
+		if handlerCalled != test.reachesNext {
+			t.Errorf("%s: handler called = %t; want %t", test.name, handlerCalled, test.reachesNext)
+		}
+
+		allowMethods := rec.Header().Get("Access-Control-Allow-Methods")
+		if wantMethods := test.preflight && test.allowOrigin != ""; (allowMethods != "") != wantMethods {
+			t.Errorf("%s: Access-Control-Allow-Methods = %q; want set %t", test.name, allowMethods, wantMethods)
+		}
+	}
+}
+
+func TestCORSMiddlewareWildcard(t *testing.T) {
+	handler := CORSMiddleware([]string{"*"})(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Origin", "https://anywhere.example")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "https://anywhere.example" {
+		t.Errorf("Access-Control-Allow-Origin = %q; want the request origin", got)
+	}
+}
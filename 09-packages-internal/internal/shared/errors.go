@@ -1,6 +1,7 @@
 package shared
 
 import (
+	"errors"
 	"fmt"
 	"runtime"
 	"strings"
@@ -20,24 +21,23 @@ func FormatValidationError(field, message string) error {
 	return fmt.Errorf("validation failed for field %q: %s", field, message)
 }
 
-// ChainErrors combines multiple errors into a single error message.
-func ChainErrors(errors []error) error {
-	if len(errors) == 0 {
-		return nil
-	}
-
-	if len(errors) == 1 {
-		return errors[0]
-	}
-
-	var messages []string
-	for _, err := range errors {
+// ChainErrors combines multiple errors into a single error.
+// Nil errors are skipped; a single error is returned as is. The combined
+// error works with errors.Is and errors.As for each of its parts.
+func ChainErrors(errs []error) error {
+	var nonNil []error
+	for _, err := range errs {
 		if err != nil {
-			messages = append(messages, err.Error())
+			nonNil = append(nonNil, err)
 		}
 	}
 
-	return fmt.Errorf("multiple errors: %s", strings.Join(messages, "; "))
+	if len(nonNil) == 1 {
+		return nonNil[0]
+	}
+
+	// errors.Join returns nil when nonNil is empty
+	return errors.Join(nonNil...)
 }
 
 // ErrorWithStack creates an error with stack trace information.
@@ -57,6 +57,67 @@ func ErrorWithStack(message string) error {
 	return fmt.Errorf("%s (at %s:%d)", message, file, line)
 }
 
+// maxStackDepth limits how many frames ErrorWithFullStack records.
+const maxStackDepth = 32
+
+// StackError is an error carrying the call stack where it was created.
+type StackError struct {
+	message string
+	stack   []string
+}
+
+// Error returns the message without the stack, keeping log lines short.
+func (e *StackError) Error() string {
+	return e.message
+}
+
+// StackTrace returns one "function (file:line)" entry per frame, innermost first.
+func (e *StackError) StackTrace() []string {
+	return append([]string(nil), e.stack...)
+}
+
+// Format implements fmt.Formatter. The %+v verb prints the message followed
+// by the stack, one frame per line; %v and %s print only the message and %q
+// quotes it.
+func (e *StackError) Format(f fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && f.Flag('+'):
+		fmt.Fprint(f, e.message)
+		for _, frame := range e.stack {
+			fmt.Fprint(f, "\n\t", frame)
+		}
+	case verb == 'v' || verb == 's':
+		fmt.Fprint(f, e.message)
+	case verb == 'q':
+		fmt.Fprintf(f, "%q", e.message)
+	default:
+		fmt.Fprintf(f, "%%!%c(*shared.StackError=%s)", verb, e.message)
+	}
+}
+
+// ErrorWithFullStack creates an error recording the full call stack, unlike
+// ErrorWithStack which only records the immediate caller. Use StackTrace to
+// read the frames, or format the error with %+v to print them.
+func ErrorWithFullStack(message string) error {
+	pcs := make([]uintptr, maxStackDepth)
+	// Skip runtime.Callers and ErrorWithFullStack itself
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	var stack []string
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, "runtime.") {
+			stack = append(stack, fmt.Sprintf("%s (%s:%d)", frame.Function, frame.File, frame.Line))
+		}
+		if !more {
+			break
+		}
+	}
+
+	return &StackError{message: message, stack: stack}
+}
+
 // RecoverError converts a panic into an error.
 // Useful for internal error handling in goroutines.
 func RecoverError() error {
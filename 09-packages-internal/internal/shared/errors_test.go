@@ -0,0 +1,146 @@
+package shared
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"testing"
+)
+
+func TestChainErrors(t *testing.T) {
+	sentinel := errors.New("sentinel")
+	other := fmt.Errorf("other failure")
+
+	chained := ChainErrors([]error{sentinel, other})
+	if !errors.Is(chained, sentinel) {
+		t.Errorf("errors.Is(ChainErrors(...), sentinel) = false; want true")
+	}
+	if !errors.Is(chained, other) {
+		t.Errorf("errors.Is(ChainErrors(...), other) = false; want true")
+	}
+	if msg := chained.Error(); !strings.Contains(msg, "sentinel") || !strings.Contains(msg, "other failure") {
+		t.Errorf("ChainErrors(...).Error() = %q; want both messages", msg)
+	}
+
+	wrapped := ChainErrors([]error{other, fmt.Errorf("open config: %w", &fs.PathError{Op: "open", Path: "/etc/app"})})
+	var pathErr *fs.PathError
+	if !errors.As(wrapped, &pathErr) || pathErr.Path != "/etc/app" {
+		t.Errorf("errors.As(ChainErrors(...), *fs.PathError) failed for %v", wrapped)
+	}
+}
+
+func TestChainErrorsSingleAndEmpty(t *testing.T) {
+	sentinel := errors.New("sentinel")
+
+	tests := []struct {
+		name string
+		errs []error
+		want error
+	}{
+		{"nil slice", nil, nil},
+		{"empty slice", []error{}, nil},
+		{"only nils", []error{nil, nil}, nil},
+		{"single", []error{sentinel}, sentinel},
+		{"single among nils", []error{nil, sentinel, nil}, sentinel},
+	}
+
+	for _, test := range tests {
+		if got := ChainErrors(test.errs); got != test.want {
+			t.Errorf("ChainErrors (%s) = %v; want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func outerStackHelper() error {
+	return innerStackHelper()
+}
+
+func innerStackHelper() error {
+	return ErrorWithFullStack("deep failure")
+}
+
+func TestErrorWithFullStack(t *testing.T) {
+	err := outerStackHelper()
+
+	if err.Error() != "deep failure" {
+		t.Errorf("Error() = %q; want %q", err.Error(), "deep failure")
+	}
+
+	var stackErr *StackError
+	if !errors.As(err, &stackErr) {
+		t.Fatalf("ErrorWithFullStack returned %T; want *StackError", err)
+	}
+
+	trace := stackErr.StackTrace()
+	index := func(name string) int {
+		for i, frame := range trace {
+			if strings.Contains(frame, name) {
+				return i
+			}
+		}
+		return -1
+	}
+
+	inner, outer, test := index("innerStackHelper"), index("outerStackHelper"), index("TestErrorWithFullStack")
+	if inner < 0 || outer < 0 || test < 0 {
+		t.Fatalf("StackTrace() = %v; want frames for both helpers and the test", trace)
+	}
+	if !(inner < outer && outer < test) {
+		t.Errorf("frames out of order (inner %d, outer %d, test %d); want innermost first", inner, outer, test)
+	}
+	if !strings.Contains(trace[inner], "errors_test.go:") {
+		t.Errorf("frame %q; want file and line", trace[inner])
+	}
+	if index("shared.ErrorWithFullStack ") >= 0 {
+		t.Errorf("StackTrace() = %v; want ErrorWithFullStack itself skipped", trace)
+	}
+}
+
+func TestStackErrorFormat(t *testing.T) {
+	err := outerStackHelper()
+	var stackErr *StackError
+	if !errors.As(err, &stackErr) {
+		t.Fatalf("ErrorWithFullStack returned %T; want *StackError", err)
+	}
+
+	// Plain verbs keep log lines short
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"%v", "deep failure"},
+		{"%s", "deep failure"},
+		{"%q", `"deep failure"`},
+		{"failed: %v", "failed: deep failure"},
+	}
+	for _, test := range tests {
+		if got := fmt.Sprintf(test.format, err); got != test.want {
+			t.Errorf("Sprintf(%q) = %q; want %q", test.format, got, test.want)
+		}
+	}
+
+	// %+v adds the stack, one frame per indented line, innermost first
+	lines := strings.Split(fmt.Sprintf("%+v", err), "\n")
+	trace := stackErr.StackTrace()
+	if len(lines) != len(trace)+1 {
+		t.Fatalf("%%+v printed %d lines; want message plus %d frames", len(lines), len(trace))
+	}
+	if lines[0] != "deep failure" {
+		t.Errorf("%%+v first line = %q; want %q", lines[0], "deep failure")
+	}
+	for i, frame := range trace {
+		if lines[i+1] != "\t"+frame {
+			t.Errorf("%%+v line %d = %q; want %q", i+1, lines[i+1], "\t"+frame)
+		}
+	}
+	if !strings.Contains(lines[1], "innerStackHelper") || !strings.Contains(lines[1], "errors_test.go:") {
+		t.Errorf("%%+v first frame = %q; want innerStackHelper with file and line", lines[1])
+	}
+
+	// Wrapping keeps the message readable
+	wrapped := fmt.Errorf("loading config: %w", err)
+	if got := wrapped.Error(); got != "loading config: deep failure" {
+		t.Errorf("wrapped Error() = %q; want %q", got, "loading config: deep failure")
+	}
+}
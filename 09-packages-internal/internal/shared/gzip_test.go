@@ -0,0 +1,125 @@
+package shared
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGzipMiddleware(t *testing.T) {
+	payload := strings.Repeat(`{"message":"hello"}`, 50)
+	handler := GzipMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, payload)
+	}))
+
+	tests := []struct {
+		acceptEncoding string
+		compressed     bool
+	}{
+		{"gzip", true},
+		{"deflate, gzip;q=0.8", true},
+		{"GZIP", true},
+		{"", false},
+		{"deflate", false},
+		{"gzip;q=0", false},
+		{"gzip; q=0.000", false},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if test.acceptEncoding != "" {
+			req.Header.Set("Accept-Encoding", test.acceptEncoding)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		encoding := rec.Header().Get("Content-Encoding")
+		body := rec.Body.String()
+		if test.compressed {
+			if encoding != "gzip" {
+				t.Errorf("Accept-Encoding %q: Content-Encoding = %q; want gzip", test.acceptEncoding, encoding)
+				continue
+			}
+			body = gunzip(t, rec.Body)
+		} else if encoding != "" {
+			t.Errorf("Accept-Encoding %q: Content-Encoding = %q; want none", test.acceptEncoding, encoding)
+		}
+
+		if body != payload {
+			t.Errorf("Accept-Encoding %q: body = %q; want the original payload", test.acceptEncoding, body)
+		}
+		if rec.Header().Get("Content-Type") != "application/json" {
+			t.Errorf("Accept-Encoding %q: Content-Type = %q; want application/json",
+				test.acceptEncoding, rec.Header().Get("Content-Type"))
+		}
+	}
+}
+
+func TestGzipMiddlewareWithLogging(t *testing.T) {
+	var logged []string
+	logger := func(format string, args ...interface{}) {
+		logged = append(logged, fmt.Sprintf(format, args...))
+	}
+
+	inner := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		WriteJSONError(w, http.StatusTeapot, "short and stout")
+	})
+
+	orders := map[string]http.Handler{
+		"logging outside": LoggingMiddleware(logger)(GzipMiddleware()(inner)),
+		"gzip outside":    GzipMiddleware()(LoggingMiddleware(logger)(inner)),
+	}
+
+	for name, handler := range orders {
+		logged = nil
+		req := httptest.NewRequest(http.MethodGet, "/tea", nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusTeapot {
+			t.Errorf("%s: status = %d; want %d", name, rec.Code, http.StatusTeapot)
+		}
+		if body := gunzip(t, rec.Body); !strings.Contains(body, "short and stout") {
+			t.Errorf("%s: body = %q; want the JSON error", name, body)
+		}
+		if len(logged) != 1 || !strings.Contains(logged[0], "418") {
+			t.Errorf("%s: log = %v; want one line with status 418", name, logged)
+		}
+	}
+}
+
+func TestGzipMiddlewareNoContent(t *testing.T) {
+	handler := GzipMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	req := httptest.NewRequest(http.MethodDelete, "/", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Header().Get("Content-Encoding") != "" || rec.Body.Len() != 0 {
+		t.Errorf("204 response has Content-Encoding %q and %d body bytes; want neither",
+			rec.Header().Get("Content-Encoding"), rec.Body.Len())
+	}
+}
+
+// gunzip decompresses r, failing the test if it is not valid gzip.
+func gunzip(t *testing.T, r io.Reader) string {
+	t.Helper()
+	reader, err := gzip.NewReader(r)
+	if err != nil {
+		t.Fatalf("gzip.NewReader: %v", err)
+	}
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("reading gzip body: %v", err)
+	}
+	return string(body)
+}
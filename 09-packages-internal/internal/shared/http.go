@@ -1,17 +1,30 @@
 package shared
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
 )
 
+// ErrResponseStarted is returned when a JSON response is written after the
+// response headers have already been sent.
+var ErrResponseStarted = errors.New("response already started")
+
+// ErrBodyTooLarge is returned by ParseJSONBodyLimited when the request body
+// exceeds the allowed size.
+var ErrBodyTooLarge = errors.New("request body too large")
+
 // HTTPError represents a structured HTTP error response.
 type HTTPError struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
 	Details string `json:"details,omitempty"`
+
+	// RequestID identifies the failed request when RequestIDMiddleware is in use.
+	RequestID string `json:"request_id,omitempty"`
 }
 
 // WriteJSONError writes a JSON error response to the HTTP response writer.
@@ -22,24 +35,46 @@ func WriteJSONError(w http.ResponseWriter, statusCode int, message string) {
 
 // WriteJSONErrorWithDetails writes a JSON error response with additional details.
 func WriteJSONErrorWithDetails(w http.ResponseWriter, statusCode int, message, details string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
-
-	response := HTTPError{
+	writeHTTPError(w, HTTPError{
 		Code:    statusCode,
 		Message: message,
 		Details: details,
+	})
+}
+
+// WriteJSONErrorCtx writes a JSON error response that includes the request ID
+// from ctx, if any, so users can quote it when reporting a problem.
+func WriteJSONErrorCtx(ctx context.Context, w http.ResponseWriter, statusCode int, message string) {
+	writeHTTPError(w, HTTPError{
+		Code:      statusCode,
+		Message:   message,
+		RequestID: RequestIDFromContext(ctx),
+	})
+}
+
+// writeHTTPError writes response as JSON with its Code as the status.
+func writeHTTPError(w http.ResponseWriter, response HTTPError) {
+	if responseStarted(w, response.Code) {
+		return
 	}
 
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.Code)
+
 	if err := json.NewEncoder(w).Encode(response); err != nil {
 		// Fallback to plain text if JSON encoding fails
 		w.Header().Set("Content-Type", "text/plain")
-		fmt.Fprintf(w, "Error: %s", message)
+		fmt.Fprintf(w, "Error: %s", response.Message)
 	}
 }
 
 // WriteJSONResponse writes a JSON response to the HTTP response writer.
+// It returns ErrResponseStarted without writing anything if the headers were already sent.
 func WriteJSONResponse(w http.ResponseWriter, statusCode int, data interface{}) error {
+	if responseStarted(w, statusCode) {
+		return ErrResponseStarted
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
 	return json.NewEncoder(w).Encode(data)
@@ -61,6 +96,24 @@ func ParseJSONBody(r *http.Request, dst interface{}) error {
 	return nil
 }
 
+// ParseJSONBodyLimited parses the JSON request body like ParseJSONBody but
+// reads at most maxBytes, returning ErrBodyTooLarge for larger bodies so a
+// huge payload cannot exhaust memory.
+func ParseJSONBodyLimited(r *http.Request, dst interface{}, maxBytes int64) error {
+	if r.Body == nil {
+		return fmt.Errorf("request body is empty")
+	}
+
+	r.Body = http.MaxBytesReader(nil, r.Body, maxBytes)
+
+	err := ParseJSONBody(r, dst)
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return fmt.Errorf("%w: limit is %d bytes", ErrBodyTooLarge, maxBytesErr.Limit)
+	}
+	return err
+}
+
 // SetCORSHeaders sets common CORS headers for API responses.
 func SetCORSHeaders(w http.ResponseWriter) {
 	w.Header().Set("Access-Control-Allow-Origin", "*")
@@ -69,31 +122,105 @@ func SetCORSHeaders(w http.ResponseWriter) {
 	w.Header().Set("Access-Control-Max-Age", "86400") // 24 hours
 }
 
+// DurationBuckets classifies request durations for log lines.
+// Requests faster than Fast are tagged "fast", those taking at least Slow are
+// tagged "slow", and everything in between is "normal".
+type DurationBuckets struct {
+	Fast time.Duration
+	Slow time.Duration
+}
+
+// DefaultDurationBuckets are the thresholds used by LoggingMiddleware.
+var DefaultDurationBuckets = DurationBuckets{
+	Fast: 100 * time.Millisecond,
+	Slow: time.Second,
+}
+
+// Classify returns the bucket name for a request duration.
+func (b DurationBuckets) Classify(d time.Duration) string {
+	switch {
+	case d < b.Fast:
+		return "fast"
+	case d >= b.Slow:
+		return "slow"
+	default:
+		return "normal"
+	}
+}
+
 // LoggingMiddleware creates a middleware that logs HTTP requests.
 // Returns a function that can wrap HTTP handlers.
 func LoggingMiddleware(logger func(string, ...interface{})) func(http.Handler) http.Handler {
+	return LoggingMiddlewareWithBuckets(logger, DefaultDurationBuckets)
+}
+
+// LoggingMiddlewareWithBuckets creates a logging middleware that tags each
+// log line with the request's duration bucket, e.g. "[slow]", so slow requests
+// are easy to grep. Requests whose context deadline expired are tagged "[timeout]".
+func LoggingMiddlewareWithBuckets(
+	logger func(string, ...interface{}), buckets DurationBuckets,
+) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			start := time.Now()
 
 			// Wrap the response writer to capture the status code
-			wrapped := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+			wrapped := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK, logger: logger}
 
 			next.ServeHTTP(wrapped, r)
 
 			duration := time.Since(start)
-			logger("HTTP %s %s - %d - %v", r.Method, r.URL.Path, wrapped.statusCode, duration)
+			bucket := buckets.Classify(duration)
+			if errors.Is(r.Context().Err(), context.DeadlineExceeded) {
+				bucket = "timeout"
+			}
+			logger("HTTP %s %s - %d - %d bytes - %v [%s]",
+				r.Method, r.URL.Path, wrapped.statusCode, wrapped.bytesWritten, duration, bucket)
 		})
 	}
 }
 
-// responseWriter wraps http.ResponseWriter to capture the status code.
+// responseWriter wraps http.ResponseWriter to capture the status code and
+// body size, and to detect writes after the headers have been sent.
 type responseWriter struct {
 	http.ResponseWriter
-	statusCode int
+	statusCode   int
+	bytesWritten int
+	wroteHeader  bool
+	logger       func(string, ...interface{})
 }
 
 func (rw *responseWriter) WriteHeader(code int) {
+	if rw.wroteHeader {
+		rw.logf("superfluous WriteHeader(%d) ignored, status %d already sent", code, rw.statusCode)
+		return
+	}
 	rw.statusCode = code
+	rw.wroteHeader = true
 	rw.ResponseWriter.WriteHeader(code)
 }
+
+func (rw *responseWriter) Write(b []byte) (int, error) {
+	// An implicit 200 is sent on the first Write
+	rw.wroteHeader = true
+	n, err := rw.ResponseWriter.Write(b)
+	rw.bytesWritten += n
+	return n, err
+}
+
+func (rw *responseWriter) logf(format string, args ...interface{}) {
+	if rw.logger != nil {
+		rw.logger(format, args...)
+	}
+}
+
+// responseStarted reports whether w has already sent its headers, logging the
+// attempted second write. Only writers wrapped by LoggingMiddleware are tracked.
+func responseStarted(w http.ResponseWriter, statusCode int) bool {
+	rw, ok := w.(*responseWriter)
+	if !ok || !rw.wroteHeader {
+		return false
+	}
+	rw.logf("JSON response with status %d dropped, status %d already sent", statusCode, rw.statusCode)
+	return true
+}
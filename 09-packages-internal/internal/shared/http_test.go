@@ -0,0 +1,166 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteJSONAfterResponseStarted(t *testing.T) {
+	var logged []string
+	logger := func(format string, args ...interface{}) {
+		logged = append(logged, fmt.Sprintf(format, args...))
+	}
+
+	var writeErr error
+	handler := LoggingMiddleware(logger)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, "partial")
+
+		WriteJSONError(w, http.StatusInternalServerError, "something broke")
+		writeErr = WriteJSONResponse(w, http.StatusOK, map[string]string{"late": "data"})
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("status = %d; want %d", rec.Code, http.StatusOK)
+	}
+	if rec.Body.String() != "partial" {
+		t.Errorf("body = %q; want %q", rec.Body.String(), "partial")
+	}
+	if !errors.Is(writeErr, ErrResponseStarted) {
+		t.Errorf("WriteJSONResponse error = %v; want ErrResponseStarted", writeErr)
+	}
+
+	dropped := 0
+	for _, line := range logged {
+		if strings.Contains(line, "dropped") {
+			dropped++
+		}
+	}
+	if dropped != 2 {
+		t.Errorf("logged %d dropped writes; want 2 (log: %v)", dropped, logged)
+	}
+}
+
+func TestDurationBucketsClassify(t *testing.T) {
+	buckets := DurationBuckets{Fast: 10 * time.Millisecond, Slow: 100 * time.Millisecond}
+
+	tests := []struct {
+		duration time.Duration
+		expected string
+	}{
+		{time.Millisecond, "fast"},
+		{10 * time.Millisecond, "normal"},
+		{99 * time.Millisecond, "normal"},
+		{100 * time.Millisecond, "slow"},
+		{5 * time.Second, "slow"},
+	}
+
+	for _, test := range tests {
+		if result := buckets.Classify(test.duration); result != test.expected {
+			t.Errorf("Classify(%v) = %q; want %q", test.duration, result, test.expected)
+		}
+	}
+}
+
+func TestLoggingMiddlewareTagsBucket(t *testing.T) {
+	var logged string
+	logger := func(format string, args ...interface{}) {
+		logged = fmt.Sprintf(format, args...)
+	}
+
+	// A zero Slow threshold makes every request slow
+	buckets := DurationBuckets{Fast: 0, Slow: 0}
+	noContent := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	handler := LoggingMiddlewareWithBuckets(logger, buckets)(noContent)
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/report", nil))
+
+	if !strings.HasPrefix(logged, "HTTP GET /report - 204 - ") || !strings.HasSuffix(logged, "[slow]") {
+		t.Errorf("log line = %q; want GET /report tagged [slow]", logged)
+	}
+}
+
+func TestLoggingMiddlewareTagsTimeout(t *testing.T) {
+	var logged string
+	logger := func(format string, args ...interface{}) {
+		logged = fmt.Sprintf(format, args...)
+	}
+
+	handler := LoggingMiddleware(logger)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+	req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if !strings.HasSuffix(logged, "[timeout]") {
+		t.Errorf("log line = %q; want tagged [timeout]", logged)
+	}
+}
+
+func TestParseJSONBodyLimited(t *testing.T) {
+	type payload struct {
+		Name string `json:"name"`
+	}
+
+	tests := []struct {
+		name     string
+		body     string
+		tooLarge bool
+		hasError bool
+	}{
+		{"within limit", `{"name": "alice"}`, false, false},
+		{"oversized", `{"name": "` + strings.Repeat("a", 100) + `"}`, true, true},
+		{"malformed within limit", `{"name": `, false, true},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(test.body))
+
+		var dst payload
+		err := ParseJSONBodyLimited(req, &dst, 64)
+
+		if test.hasError != (err != nil) {
+			t.Errorf("%s: ParseJSONBodyLimited error = %v; want error %t", test.name, err, test.hasError)
+		}
+		if errors.Is(err, ErrBodyTooLarge) != test.tooLarge {
+			t.Errorf("%s: ParseJSONBodyLimited error = %v; want ErrBodyTooLarge %t", test.name, err, test.tooLarge)
+		}
+		if !test.hasError && dst.Name != "alice" {
+			t.Errorf("%s: Name = %q; want %q", test.name, dst.Name, "alice")
+		}
+	}
+}
+
+func TestLoggingMiddlewareLogsResponseSize(t *testing.T) {
+	var logged string
+	logger := func(format string, args ...interface{}) {
+		logged = fmt.Sprintf(format, args...)
+	}
+
+	handler := LoggingMiddleware(logger)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if err := WriteJSONResponse(w, http.StatusOK, map[string]string{"status": "healthy"}); err != nil {
+			t.Errorf("WriteJSONResponse unexpected error: %v", err)
+		}
+		fmt.Fprint(w, "trailer")
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/status", nil))
+
+	want := fmt.Sprintf(" - %d bytes - ", rec.Body.Len())
+	if !strings.Contains(logged, want) {
+		t.Errorf("log line = %q; want it to contain %q", logged, want)
+	}
+}
@@ -0,0 +1,41 @@
+package shared
+
+import (
+	"net/http"
+)
+
+// Chain composes middlewares into one, applied left to right: the first
+// middleware is the outermost and sees the request first.
+func Chain(middlewares ...func(http.Handler) http.Handler) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		for i := len(middlewares) - 1; i >= 0; i-- {
+			next = middlewares[i](next)
+		}
+		return next
+	}
+}
+
+// RecoveryMiddleware creates a middleware that recovers from panics in the
+// wrapped handler, logs them with the request path, and responds with a
+// 500 JSON error so one bad handler cannot crash the server.
+func RecoveryMiddleware(logger func(string, ...interface{})) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				recovered := recover()
+				if recovered == nil {
+					return
+				}
+				if recovered == http.ErrAbortHandler {
+					// Let net/http abort the response as the handler intended
+					panic(recovered)
+				}
+
+				logger("panic serving %s %s: %v", r.Method, r.URL.Path, recovered)
+				WriteJSONError(w, http.StatusInternalServerError, "internal error")
+			}()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
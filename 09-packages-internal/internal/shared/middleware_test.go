@@ -0,0 +1,95 @@
+package shared
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRecoveryMiddleware(t *testing.T) {
+	var logged []string
+	logger := func(format string, args ...interface{}) {
+		logged = append(logged, fmt.Sprintf(format, args...))
+	}
+
+	handler := RecoveryMiddleware(logger)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic("nil map write")
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/42", nil))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d; want %d", rec.Code, http.StatusInternalServerError)
+	}
+
+	var body HTTPError
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("decoding response body: %v", err)
+	}
+	if body.Code != http.StatusInternalServerError || body.Message != "internal error" {
+		t.Errorf("body = %+v; want code 500 and message %q", body, "internal error")
+	}
+
+	if len(logged) != 1 || !strings.Contains(logged[0], "/users/42") || !strings.Contains(logged[0], "nil map write") {
+		t.Errorf("log = %v; want one line with the path and panic value", logged)
+	}
+}
+
+func TestRecoveryMiddlewarePassesThrough(t *testing.T) {
+	logger := func(format string, args ...interface{}) {
+		t.Errorf("unexpected log: "+format, args...)
+	}
+
+	handler := RecoveryMiddleware(logger)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/users", nil))
+
+	if rec.Code != http.StatusCreated {
+		t.Errorf("status = %d; want %d", rec.Code, http.StatusCreated)
+	}
+}
+
+func TestChainOrder(t *testing.T) {
+	tag := func(name string) func(http.Handler) http.Handler {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Add("X-Trace", name)
+				next.ServeHTTP(w, r)
+			})
+		}
+	}
+
+	handler := Chain(tag("first"), tag("second"), tag("third"))(
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Add("X-Trace", "handler")
+		}),
+	)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	got := strings.Join(rec.Header().Values("X-Trace"), ",")
+	if want := "first,second,third,handler"; got != want {
+		t.Errorf("X-Trace = %q; want %q", got, want)
+	}
+}
+
+func TestChainEmpty(t *testing.T) {
+	handler := Chain()(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rec.Code != http.StatusAccepted {
+		t.Errorf("status = %d; want %d", rec.Code, http.StatusAccepted)
+	}
+}
@@ -0,0 +1,113 @@
+package shared
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimitMiddleware creates a middleware that limits each client IP to rps
+// requests per second on average, allowing bursts of up to burst requests.
+// Requests over the limit receive a 429 JSON error. It panics if rps is not
+// positive; a burst below 1 is treated as 1.
+func RateLimitMiddleware(rps int, burst int) func(http.Handler) http.Handler {
+	if rps <= 0 {
+		panic("shared: RateLimitMiddleware requires a positive rps")
+	}
+	limiter := newRateLimiter(float64(rps), float64(max(burst, 1)))
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !limiter.allow(clientIP(r)) {
+				w.Header().Set("Retry-After", strconv.Itoa(int(limiter.refillTime().Seconds())+1))
+				WriteJSONError(w, http.StatusTooManyRequests, "rate limit exceeded")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// clientIP returns the host part of the request's remote address.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// rateLimiter keeps a token bucket per client key.
+type rateLimiter struct {
+	mu        sync.Mutex
+	rate      float64 // Tokens added per second
+	burst     float64 // Bucket capacity
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+	now       func() time.Time // Clock, replaceable in tests
+}
+
+// tokenBucket holds a client's remaining tokens as of last.
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(rate, burst float64) *rateLimiter {
+	return &rateLimiter{
+		rate:    rate,
+		burst:   burst,
+		buckets: make(map[string]*tokenBucket),
+		now:     time.Now,
+	}
+}
+
+// allow refills key's bucket for the time since its last request and takes
+// one token, reporting false if none was available.
+func (l *rateLimiter) allow(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	bucket, exists := l.buckets[key]
+	if !exists {
+		bucket = &tokenBucket{tokens: l.burst, last: now}
+		l.buckets[key] = bucket
+	}
+
+	bucket.tokens = min(l.burst, bucket.tokens+now.Sub(bucket.last).Seconds()*l.rate)
+	bucket.last = now
+
+	if bucket.tokens < 1 {
+		return false
+	}
+	bucket.tokens--
+	return true
+}
+
+// refillTime is how long an empty bucket takes to fill completely.
+func (l *rateLimiter) refillTime() time.Duration {
+	return time.Duration(l.burst / l.rate * float64(time.Second))
+}
+
+// sweep drops buckets idle long enough to have refilled, since they behave
+// exactly like new ones. It runs at most once per refill time so memory
+// stays bounded by the clients seen recently.
+// The caller must hold l.mu.
+func (l *rateLimiter) sweep(now time.Time) {
+	idle := l.refillTime()
+	if now.Sub(l.lastSweep) < idle {
+		return
+	}
+	l.lastSweep = now
+
+	for key, bucket := range l.buckets {
+		if now.Sub(bucket.last) >= idle {
+			delete(l.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package shared
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRateLimitMiddleware(t *testing.T) {
+	handler := RateLimitMiddleware(1, 3)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	send := func(remoteAddr string) int {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = remoteAddr
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	counts := map[int]int{}
+	for i := 0; i < 5; i++ {
+		counts[send("10.0.0.1:1234")]++
+	}
+	if counts[http.StatusOK] != 3 || counts[http.StatusTooManyRequests] != 2 {
+		t.Errorf("burst of 5 got %d OK and %d Too Many Requests; want 3 and 2",
+			counts[http.StatusOK], counts[http.StatusTooManyRequests])
+	}
+
+	// The same IP on a different port shares the bucket
+	if code := send("10.0.0.1:5678"); code != http.StatusTooManyRequests {
+		t.Errorf("same IP, new port status = %d; want %d", code, http.StatusTooManyRequests)
+	}
+
+	// Other clients have their own bucket
+	if code := send("10.0.0.2:1234"); code != http.StatusOK {
+		t.Errorf("other IP status = %d; want %d", code, http.StatusOK)
+	}
+}
+
+func TestRateLimiterRefillAndSweep(t *testing.T) {
+	clock := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	limiter := newRateLimiter(2, 2)
+	limiter.now = func() time.Time { return clock }
+
+	for i, want := range []bool{true, true, false} {
+		if got := limiter.allow("a"); got != want {
+			t.Errorf("request %d: allow = %t; want %t", i+1, got, want)
+		}
+	}
+
+	// Half a second refills one token at 2 per second
+	clock = clock.Add(500 * time.Millisecond)
+	if !limiter.allow("a") {
+		t.Error("allow after refill = false; want true")
+	}
+	if limiter.allow("a") {
+		t.Error("allow after using the refilled token = true; want false")
+	}
+
+	limiter.allow("b")
+	if len(limiter.buckets) != 2 {
+		t.Fatalf("buckets = %d; want 2", len(limiter.buckets))
+	}
+
+	// Idle buckets are removed once they would have refilled
+	clock = clock.Add(2 * time.Second)
+	limiter.allow("c")
+	if len(limiter.buckets) != 1 {
+		t.Errorf("buckets after sweep = %d; want 1", len(limiter.buckets))
+	}
+}
@@ -0,0 +1,56 @@
+package shared
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	var seen string
+	handler := RequestIDMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = RequestIDFromContext(r.Context())
+		WriteJSONErrorCtx(r.Context(), w, http.StatusNotFound, "user not found")
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/7", nil))
+
+	header := rec.Header().Get(RequestIDHeader)
+	if header == "" {
+		t.Fatal("X-Request-ID header not set")
+	}
+	if seen != header {
+		t.Errorf("context request ID = %q; want header value %q", seen, header)
+	}
+
+	var body HTTPError
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("decoding response body: %v", err)
+	}
+	if body.RequestID != header || body.Code != http.StatusNotFound || body.Message != "user not found" {
+		t.Errorf("body = %+v; want code 404, message and request ID %q", body, header)
+	}
+
+	// Each request gets its own ID
+	second := httptest.NewRecorder()
+	handler.ServeHTTP(second, httptest.NewRequest(http.MethodGet, "/users/7", nil))
+	if second.Header().Get(RequestIDHeader) == header {
+		t.Error("two requests were given the same ID")
+	}
+}
+
+func TestWriteJSONErrorOmitsMissingRequestID(t *testing.T) {
+	rec := httptest.NewRecorder()
+	WriteJSONErrorCtx(context.Background(), rec, http.StatusBadRequest, "bad input")
+
+	if strings.Contains(rec.Body.String(), "request_id") {
+		t.Errorf("body = %q; want no request_id field", rec.Body.String())
+	}
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d; want %d", rec.Code, http.StatusBadRequest)
+	}
+}
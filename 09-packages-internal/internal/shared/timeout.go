@@ -0,0 +1,117 @@
+package shared
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// TimeoutMiddleware creates a middleware that gives each request d to finish.
+// The deadline is set on the request context so handlers can observe the
+// cancellation and stop work. If the handler is still running when the
+// deadline passes, the client gets a 503 JSON error and anything the handler
+// writes afterwards is discarded.
+func TimeoutMiddleware(d time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithTimeout(r.Context(), d)
+			defer cancel()
+			r = r.WithContext(ctx)
+
+			// The handler writes to a buffer so a late write cannot race
+			// with the timeout response
+			tw := &timeoutWriter{header: make(http.Header)}
+			done := make(chan struct{})
+			panicked := make(chan interface{}, 1)
+
+			go func() {
+				defer func() {
+					if p := recover(); p != nil {
+						panicked <- p
+						return
+					}
+					close(done)
+				}()
+				next.ServeHTTP(tw, r)
+			}()
+
+			select {
+			case p := <-panicked:
+				// Re-panic on the serving goroutine so RecoveryMiddleware sees it
+				panic(p)
+			case <-done:
+				tw.flushTo(w)
+			case <-ctx.Done():
+				tw.expire()
+				if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+					WriteJSONError(w, http.StatusServiceUnavailable, "request timed out")
+				}
+			}
+		})
+	}
+}
+
+// timeoutWriter buffers a handler's response until it finishes in time.
+type timeoutWriter struct {
+	mu          sync.Mutex
+	header      http.Header
+	body        bytes.Buffer
+	code        int
+	wroteHeader bool
+	timedOut    bool
+}
+
+func (tw *timeoutWriter) Header() http.Header {
+	return tw.header
+}
+
+func (tw *timeoutWriter) WriteHeader(code int) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.timedOut || tw.wroteHeader {
+		return
+	}
+	tw.code = code
+	tw.wroteHeader = true
+}
+
+func (tw *timeoutWriter) Write(b []byte) (int, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	if !tw.wroteHeader {
+		tw.code = http.StatusOK
+		tw.wroteHeader = true
+	}
+	return tw.body.Write(b)
+}
+
+// expire discards the buffered response and rejects later writes.
+func (tw *timeoutWriter) expire() {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	tw.timedOut = true
+}
+
+// flushTo copies the buffered response to w.
+func (tw *timeoutWriter) flushTo(w http.ResponseWriter) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	for key, values := range tw.header {
+		w.Header()[key] = values
+	}
+	if !tw.wroteHeader {
+		tw.code = http.StatusOK
+	}
+	w.WriteHeader(tw.code)
+	w.Write(tw.body.Bytes()) //nolint:errcheck // The client may have gone away
+}
@@ -0,0 +1,81 @@
+package shared
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTimeoutMiddlewareSlowHandler(t *testing.T) {
+	cancelled := make(chan struct{})
+	handler := TimeoutMiddleware(20 * time.Millisecond)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+			close(cancelled)
+		case <-time.After(time.Second):
+		}
+		w.Write([]byte("too late"))
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/report", nil))
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("status = %d; want %d", rec.Code, http.StatusServiceUnavailable)
+	}
+
+	var body HTTPError
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("decoding response body: %v", err)
+	}
+	if body.Code != http.StatusServiceUnavailable {
+		t.Errorf("body code = %d; want %d", body.Code, http.StatusServiceUnavailable)
+	}
+
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Error("handler never observed the context cancellation")
+	}
+}
+
+func TestTimeoutMiddlewareFastHandler(t *testing.T) {
+	handler := TimeoutMiddleware(time.Second)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.Context().Deadline(); !ok {
+			t.Error("request context has no deadline")
+		}
+		w.Header().Set("X-Handler", "fast")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("done"))
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/items", nil))
+
+	if rec.Code != http.StatusCreated {
+		t.Errorf("status = %d; want %d", rec.Code, http.StatusCreated)
+	}
+	if rec.Body.String() != "done" {
+		t.Errorf("body = %q; want %q", rec.Body.String(), "done")
+	}
+	if rec.Header().Get("X-Handler") != "fast" {
+		t.Errorf("X-Handler = %q; want %q", rec.Header().Get("X-Handler"), "fast")
+	}
+}
+
+func TestTimeoutMiddlewarePropagatesPanic(t *testing.T) {
+	handler := RecoveryMiddleware(func(string, ...interface{}) {})(
+		TimeoutMiddleware(time.Second)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+			panic("boom")
+		})),
+	)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d; want %d", rec.Code, http.StatusInternalServerError)
+	}
+}
@@ -12,10 +12,18 @@
 package calculator
 
 import (
+	"encoding/csv"
 	"errors"
 	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
 )
 
+// ErrOverflow is returned when an integer result does not fit in an int.
+var ErrOverflow = errors.New("integer overflow")
+
 // Add returns the sum of two integers.
 func Add(a, b int) int {
 	return a + b
@@ -31,6 +39,30 @@ func Multiply(a, b int) int {
 	return multiply(a, b)
 }
 
+// AddChecked returns the sum of two integers, or an error wrapping
+// ErrOverflow if the sum does not fit in an int. Add wraps around instead.
+func AddChecked(a, b int) (int, error) {
+	if (b > 0 && a > math.MaxInt-b) || (b < 0 && a < math.MinInt-b) {
+		return 0, fmt.Errorf("%d + %d: %w", a, b, ErrOverflow)
+	}
+	return a + b, nil
+}
+
+// MultiplyChecked returns the product of two integers, or an error wrapping
+// ErrOverflow if the product does not fit in an int. Multiply wraps around instead.
+func MultiplyChecked(a, b int) (int, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+
+	result := multiply(a, b)
+	// -1 * MinInt wraps to MinInt, and MinInt / -1 also wraps, so check it explicitly
+	if result/b != a || (a == -1 && b == math.MinInt) || (b == -1 && a == math.MinInt) {
+		return 0, fmt.Errorf("%d * %d: %w", a, b, ErrOverflow)
+	}
+	return result, nil
+}
+
 // Divide returns the quotient of two float64 numbers.
 // It returns an error if the divisor is zero.
 func Divide(a, b float64) (float64, error) {
@@ -40,11 +72,96 @@ func Divide(a, b float64) (float64, error) {
 	return a / b, nil
 }
 
+// Modulo returns the remainder of a divided by b.
+// The result takes the sign of a, following Go's % operator.
+// It returns an error if the divisor is zero.
+func Modulo(a, b int) (int, error) {
+	if b == 0 {
+		return 0, errors.New("division by zero")
+	}
+	return a % b, nil
+}
+
+// IntDivide returns the integer quotient and remainder of a divided by b.
+// Both follow Go's truncated division, so IntDivide(-7, 2) returns -3 and -1.
+// It returns an error if the divisor is zero.
+func IntDivide(a, b int) (quotient, remainder int, err error) {
+	if b == 0 {
+		return 0, 0, errors.New("division by zero")
+	}
+	return a / b, a % b, nil
+}
+
+// GCD returns the greatest common divisor of a and b using the Euclidean algorithm.
+// Negative inputs are treated as their absolute values, and GCD(0, 0) is 0.
+func GCD(a, b int) int {
+	a, b = abs(a), abs(b)
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// LCM returns the least common multiple of a and b, built on GCD.
+// Negative inputs are treated as their absolute values, and LCM is 0 when either argument is 0.
+func LCM(a, b int) int {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return abs(a) / GCD(a, b) * abs(b)
+}
+
+// Factorial returns n! computed iteratively with the multiply helper.
+// It returns an error for negative n, or if the result would overflow int
+// (any n > 20 on 64-bit platforms).
+func Factorial(n int) (int, error) {
+	if n < 0 {
+		return 0, fmt.Errorf("factorial of negative number %d", n)
+	}
+
+	result := 1
+	for i := 2; i <= n; i++ {
+		if result > math.MaxInt/i {
+			return 0, fmt.Errorf("factorial of %d: %w", n, ErrOverflow)
+		}
+		result = multiply(result, i)
+	}
+	return result, nil
+}
+
+// PercentOf returns what percentage part is of whole.
+// It returns an error if whole is zero.
+func PercentOf(part, whole float64) (float64, error) {
+	if whole == 0 {
+		return 0, errors.New("percentage of zero whole")
+	}
+	return part / whole * 100, nil
+}
+
+// PercentChange returns the relative change from oldValue to newValue as a percentage.
+// An increase is positive and a decrease is negative, regardless of the sign of
+// oldValue, so PercentChange(50, 75) = 50 and PercentChange(-50, -25) = 50.
+// It returns an error if oldValue is zero.
+func PercentChange(oldValue, newValue float64) (float64, error) {
+	if oldValue == 0 {
+		return 0, errors.New("percent change from zero")
+	}
+	return (newValue - oldValue) / math.Abs(oldValue) * 100, nil
+}
+
 // multiply is an unexported function that can only be used within the calculator package.
 func multiply(a, b int) int {
 	return a * b
 }
 
+// abs returns the absolute value of n.
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
 // power is another unexported helper function.
 func power(base, exp int) int {
 	if exp == 0 {
@@ -62,25 +179,96 @@ func Power(base, exp int) int {
 	return power(base, exp)
 }
 
+// PowerFloat returns base raised to the power of exp, including negative
+// exponents: PowerFloat(2, -2) is 1/2² = 0.25.
+// A zero base with a negative exponent returns an infinity, matching
+// math.Pow: -Inf for -0 with an odd exponent and +Inf otherwise.
+// It runs in O(log |exp|) steps by repeated squaring.
+func PowerFloat(base float64, exp int) float64 {
+	if exp >= 0 {
+		return powerUint(base, uint(exp))
+	}
+
+	// -(exp+1) cannot overflow, even for math.MinInt
+	n := uint(-(exp + 1)) + 1
+	if base == 0 {
+		if n%2 == 1 {
+			return math.Inf(int(math.Copysign(1, base)))
+		}
+		return math.Inf(1)
+	}
+	return 1 / powerUint(base, n)
+}
+
+// powerUint returns base raised to the power of n by exponentiation by squaring.
+func powerUint(base float64, n uint) float64 {
+	result := 1.0
+	for n > 0 {
+		if n&1 == 1 {
+			result *= base
+		}
+		base *= base
+		n >>= 1
+	}
+	return result
+}
+
 // Operation represents a single arithmetic operation.
 type Operation struct {
-	Type   string // "add", "subtract", "multiply", "divide"
-	A, B   int    // operands (for float operations, these are converted)
-	Result int    // result (for float operations, this is truncated)
+	Type      string    // "add", "subtract", "multiply", "modulo", "intdivide"
+	A, B      int       // operands
+	Result    int       // result
+	Timestamp time.Time // when the operation was recorded
+}
+
+// FloatOperation represents a single floating-point operation.
+// Float operations are kept in their own history so results are never truncated.
+type FloatOperation struct {
+	Type   string  // "divide", "percentof", "percentchange"
+	A, B   float64 // operands
+	Result float64 // result
+}
+
+// Stats summarizes a calculator's integer operation history.
+type Stats struct {
+	Total    int            // number of operations
+	ByType   map[string]int // operation count per Type
+	Sum      int            // sum of all results
+	Min, Max int            // smallest and largest result
 }
 
 // Calculator provides arithmetic operations with history tracking.
 type Calculator struct {
-	history []Operation
+	history      []Operation // integer operations, a ring buffer of size limit when one is set
+	head         int         // index of the oldest operation in history
+	count        int         // number of operations held in history
+	floatHistory []FloatOperation
+	redoStack    []Operation      // operations removed by Undo, most recent last
+	limit        int              // maximum integer history length, 0 means unlimited
+	now          func() time.Time // clock used for timestamps, replaceable in tests
 }
 
 // NewCalculator creates a new Calculator instance.
 func NewCalculator() *Calculator {
 	return &Calculator{
-		history: make([]Operation, 0),
+		history:      make([]Operation, 0),
+		floatHistory: make([]FloatOperation, 0),
+		now:          time.Now,
 	}
 }
 
+// NewCalculatorWithLimit creates a Calculator that keeps only the most recent
+// limit integer operations, discarding the oldest when full.
+// A limit of zero or less means the history is unlimited, like NewCalculator.
+func NewCalculatorWithLimit(limit int) *Calculator {
+	calc := NewCalculator()
+	if limit > 0 {
+		calc.limit = limit
+		calc.history = make([]Operation, limit)
+	}
+	return calc
+}
+
 // Add performs addition and records the operation in history.
 func (c *Calculator) Add(a, b int) int {
 	result := Add(a, b)
@@ -102,21 +290,248 @@ func (c *Calculator) Multiply(a, b int) int {
 	return result
 }
 
-// GetHistory returns a copy of the operation history.
+// Last returns the most recent integer result, or false if the history is empty.
+func (c *Calculator) Last() (int, bool) {
+	if c.count == 0 {
+		return 0, false
+	}
+	return c.at(c.count - 1).Result, true
+}
+
+// ContinueAdd adds b to the last result and records it as an "add" operation,
+// so 5 + 3 followed by ContinueAdd(2) yields 10. With no history it starts from 0.
+func (c *Calculator) ContinueAdd(b int) int {
+	last, _ := c.Last()
+	return c.Add(last, b)
+}
+
+// Modulo computes a remainder and records the operation in history.
+func (c *Calculator) Modulo(a, b int) (int, error) {
+	result, err := Modulo(a, b)
+	if err != nil {
+		return 0, err
+	}
+	c.recordOperation("modulo", a, b, result)
+	return result, nil
+}
+
+// IntDivide performs integer division and records the operation in history.
+// The recorded result is the quotient.
+func (c *Calculator) IntDivide(a, b int) (quotient, remainder int, err error) {
+	quotient, remainder, err = IntDivide(a, b)
+	if err != nil {
+		return 0, 0, err
+	}
+	c.recordOperation("intdivide", a, b, quotient)
+	return quotient, remainder, nil
+}
+
+// Divide performs division and records the operation in the float history.
+func (c *Calculator) Divide(a, b float64) (float64, error) {
+	result, err := Divide(a, b)
+	if err != nil {
+		return 0, err
+	}
+	c.recordFloatOperation("divide", a, b, result)
+	return result, nil
+}
+
+// PercentOf computes a percentage and records the operation in the float history.
+func (c *Calculator) PercentOf(part, whole float64) (float64, error) {
+	result, err := PercentOf(part, whole)
+	if err != nil {
+		return 0, err
+	}
+	c.recordFloatOperation("percentof", part, whole, result)
+	return result, nil
+}
+
+// PercentChange computes a percent change and records the operation in the float history.
+func (c *Calculator) PercentChange(oldValue, newValue float64) (float64, error) {
+	result, err := PercentChange(oldValue, newValue)
+	if err != nil {
+		return 0, err
+	}
+	c.recordFloatOperation("percentchange", oldValue, newValue, result)
+	return result, nil
+}
+
+// GetHistory returns a copy of the operation history, oldest first.
 func (c *Calculator) GetHistory() []Operation {
-	historyCopy := make([]Operation, len(c.history))
-	copy(historyCopy, c.history)
+	historyCopy := make([]Operation, c.count)
+	if c.count == 0 {
+		return historyCopy
+	}
+
+	// Unroll the ring: the run from head to the end of the buffer, then the
+	// wrapped-around remainder from the start
+	n := copy(historyCopy, c.history[c.head:min(c.head+c.count, len(c.history))])
+	copy(historyCopy[n:], c.history)
 	return historyCopy
 }
 
-// ClearHistory clears the operation history.
+// at returns the i-th oldest operation in the integer history.
+func (c *Calculator) at(i int) Operation {
+	return c.history[(c.head+i)%len(c.history)]
+}
+
+// Stats returns summary statistics over the integer operation history.
+// An empty calculator returns the zero Stats.
+func (c *Calculator) Stats() Stats {
+	if c.count == 0 {
+		return Stats{}
+	}
+
+	stats := Stats{
+		ByType: make(map[string]int),
+		Min:    c.at(0).Result,
+		Max:    c.at(0).Result,
+	}
+	for i := range c.count {
+		op := c.at(i)
+		stats.Total++
+		stats.ByType[op.Type]++
+		stats.Sum += op.Result
+		stats.Min = min(stats.Min, op.Result)
+		stats.Max = max(stats.Max, op.Result)
+	}
+	return stats
+}
+
+// SumResults returns the sum of all recorded integer results.
+func (c *Calculator) SumResults() int {
+	sum := 0
+	for i := range c.count {
+		sum += c.at(i).Result
+	}
+	return sum
+}
+
+// AverageResult returns the mean of all recorded integer results,
+// or 0 if the history is empty.
+func (c *Calculator) AverageResult() float64 {
+	if c.count == 0 {
+		return 0
+	}
+	return float64(c.SumResults()) / float64(c.count)
+}
+
+// WriteCSV writes the integer history to w as CSV, starting with a
+// "type,a,b,result" header row followed by one row per operation.
+func (c *Calculator) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write([]string{"type", "a", "b", "result"}); err != nil {
+		return err
+	}
+	for i := range c.count {
+		op := c.at(i)
+		record := []string{op.Type, strconv.Itoa(op.A), strconv.Itoa(op.B), strconv.Itoa(op.Result)}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// Undo removes the most recent operation from history and returns it.
+// It returns false if the history is empty.
+func (c *Calculator) Undo() (Operation, bool) {
+	if c.count == 0 {
+		return Operation{}, false
+	}
+
+	last := c.at(c.count - 1)
+	c.count--
+	c.redoStack = append(c.redoStack, last)
+	return last, true
+}
+
+// Redo re-applies the most recently undone operation and returns it.
+// It returns false if there is nothing to redo. Recording any new
+// operation discards the redo stack.
+func (c *Calculator) Redo() (Operation, bool) {
+	if len(c.redoStack) == 0 {
+		return Operation{}, false
+	}
+
+	last := c.redoStack[len(c.redoStack)-1]
+	c.redoStack = c.redoStack[:len(c.redoStack)-1]
+	c.appendHistory(last)
+	return last, true
+}
+
+// HistoryByType returns a copy of the operations whose Type matches op, in order.
+func (c *Calculator) HistoryByType(op string) []Operation {
+	filtered := make([]Operation, 0)
+	for i := range c.count {
+		if operation := c.at(i); operation.Type == op {
+			filtered = append(filtered, operation)
+		}
+	}
+	return filtered
+}
+
+// GetFloatHistory returns a copy of the floating-point operation history.
+func (c *Calculator) GetFloatHistory() []FloatOperation {
+	historyCopy := make([]FloatOperation, len(c.floatHistory))
+	copy(historyCopy, c.floatHistory)
+	return historyCopy
+}
+
+// ClearHistory clears both the integer and floating-point operation history.
 func (c *Calculator) ClearHistory() {
-	c.history = c.history[:0]
+	clear(c.history)
+	c.head, c.count = 0, 0
+	c.floatHistory = c.floatHistory[:0]
+	c.redoStack = nil
 }
 
 // recordOperation is an unexported method that records operations in the history.
+// A new operation invalidates any undone operations.
 func (c *Calculator) recordOperation(op string, a, b, result int) {
-	c.history = append(c.history, Operation{
+	c.redoStack = nil
+	c.appendHistory(Operation{
+		Type:      op,
+		A:         a,
+		B:         b,
+		Result:    result,
+		Timestamp: c.clock(),
+	})
+}
+
+// clock returns the current time from the injected clock, falling back to
+// time.Now for a zero-value Calculator.
+func (c *Calculator) clock() time.Time {
+	if c.now == nil {
+		return time.Now()
+	}
+	return c.now()
+}
+
+// appendHistory adds op to the integer history. When a limit is set the
+// history is a ring buffer of that size, so a full history overwrites its
+// oldest operation in constant time instead of shifting the rest down.
+func (c *Calculator) appendHistory(op Operation) {
+	switch {
+	case c.count < len(c.history):
+		c.history[(c.head+c.count)%len(c.history)] = op
+		c.count++
+	case c.limit > 0:
+		c.history[c.head] = op
+		c.head = (c.head + 1) % len(c.history)
+	default:
+		// Unlimited histories never drop operations, so head stays at 0
+		c.history = append(c.history, op)
+		c.count++
+	}
+}
+
+// recordFloatOperation records a floating-point operation in the float history.
+func (c *Calculator) recordFloatOperation(op string, a, b, result float64) {
+	c.floatHistory = append(c.floatHistory, FloatOperation{
 		Type:   op,
 		A:      a,
 		B:      b,
@@ -126,13 +541,27 @@ func (c *Calculator) recordOperation(op string, a, b, result int) {
 
 // String returns a string representation of the calculator's history.
 func (c *Calculator) String() string {
-	if len(c.history) == 0 {
+	return c.format(false)
+}
+
+// StringWithTimestamps is like String but also shows when each operation was recorded.
+func (c *Calculator) StringWithTimestamps() string {
+	return c.format(true)
+}
+
+// format renders the integer history, optionally with timestamps.
+func (c *Calculator) format(withTimestamps bool) string {
+	if c.count == 0 {
 		return "Calculator with no operations"
 	}
 
-	result := fmt.Sprintf("Calculator with %d operations:\n", len(c.history))
-	for i, op := range c.history {
-		result += fmt.Sprintf("  %d. %s(%d, %d) = %d\n", i+1, op.Type, op.A, op.B, op.Result)
+	result := fmt.Sprintf("Calculator with %d operations:\n", c.count)
+	for i, op := range c.GetHistory() {
+		result += fmt.Sprintf("  %d. %s(%d, %d) = %d", i+1, op.Type, op.A, op.B, op.Result)
+		if withTimestamps {
+			result += fmt.Sprintf(" at %s", op.Timestamp.Format(time.RFC3339))
+		}
+		result += "\n"
 	}
 	return result
 }
@@ -1,6 +1,13 @@
 package calculator
 
-import "testing"
+import (
+	"bytes"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
 
 func TestAdd(t *testing.T) {
 	tests := []struct {
@@ -156,3 +163,729 @@ func TestCalculator(t *testing.T) {
 		t.Errorf("Calculator history after clear = %d; want 0", len(history))
 	}
 }
+
+func TestPercentOf(t *testing.T) {
+	tests := []struct {
+		part, whole float64
+		expected    float64
+		hasError    bool
+	}{
+		{25, 200, 12.5, false},
+		{50, 50, 100, false},
+		{-10, 40, -25, false},
+		{5, 0, 0, true}, // zero whole
+	}
+
+	for _, test := range tests {
+		result, err := PercentOf(test.part, test.whole)
+
+		if test.hasError {
+			if err == nil {
+				t.Errorf("PercentOf(%f, %f) expected error but got none", test.part, test.whole)
+			}
+		} else {
+			if err != nil {
+				t.Errorf("PercentOf(%f, %f) unexpected error: %v", test.part, test.whole, err)
+			}
+			if result != test.expected {
+				t.Errorf("PercentOf(%f, %f) = %f; want %f", test.part, test.whole, result, test.expected)
+			}
+		}
+	}
+}
+
+// PercentChange reports increases as positive and decreases as negative,
+// measured relative to the magnitude of the old value.
+func TestPercentChange(t *testing.T) {
+	tests := []struct {
+		oldValue, newValue float64
+		expected           float64
+		hasError           bool
+	}{
+		{50, 75, 50, false},   // increase is positive
+		{80, 60, -25, false},  // decrease is negative
+		{-50, -25, 50, false}, // increase from a negative base is still positive
+		{-50, -100, -100, false},
+		{10, 10, 0, false},
+		{0, 10, 0, true}, // zero old value
+	}
+
+	for _, test := range tests {
+		result, err := PercentChange(test.oldValue, test.newValue)
+
+		if test.hasError {
+			if err == nil {
+				t.Errorf("PercentChange(%f, %f) expected error but got none", test.oldValue, test.newValue)
+			}
+		} else {
+			if err != nil {
+				t.Errorf("PercentChange(%f, %f) unexpected error: %v", test.oldValue, test.newValue, err)
+			}
+			if result != test.expected {
+				t.Errorf("PercentChange(%f, %f) = %f; want %f", test.oldValue, test.newValue, result, test.expected)
+			}
+		}
+	}
+}
+
+func TestCalculatorPercent(t *testing.T) {
+	calc := NewCalculator()
+
+	if _, err := calc.PercentOf(25, 200); err != nil {
+		t.Fatalf("Calculator.PercentOf(25, 200) unexpected error: %v", err)
+	}
+	if _, err := calc.PercentChange(50, 75); err != nil {
+		t.Fatalf("Calculator.PercentChange(50, 75) unexpected error: %v", err)
+	}
+	if _, err := calc.PercentOf(1, 0); err == nil {
+		t.Error("Calculator.PercentOf(1, 0) expected error but got none")
+	}
+
+	history := calc.GetFloatHistory()
+	if len(history) != 2 {
+		t.Fatalf("Calculator float history length = %d; want 2", len(history))
+	}
+	if history[0].Type != "percentof" || history[0].Result != 12.5 {
+		t.Errorf("First operation incorrect: %+v", history[0])
+	}
+	if history[1].Type != "percentchange" || history[1].Result != 50 {
+		t.Errorf("Second operation incorrect: %+v", history[1])
+	}
+	if len(calc.GetHistory()) != 0 {
+		t.Errorf("Calculator integer history length = %d; want 0", len(calc.GetHistory()))
+	}
+}
+
+func TestModulo(t *testing.T) {
+	tests := []struct {
+		a, b, expected int
+		hasError       bool
+	}{
+		{7, 3, 1, false},
+		{6, 3, 0, false},
+		{-7, 3, -1, false}, // sign follows the dividend
+		{7, -3, 1, false},
+		{-7, -3, -1, false},
+		{7, 0, 0, true}, // division by zero
+	}
+
+	for _, test := range tests {
+		result, err := Modulo(test.a, test.b)
+
+		if test.hasError {
+			if err == nil {
+				t.Errorf("Modulo(%d, %d) expected error but got none", test.a, test.b)
+			}
+		} else {
+			if err != nil {
+				t.Errorf("Modulo(%d, %d) unexpected error: %v", test.a, test.b, err)
+			}
+			if result != test.expected {
+				t.Errorf("Modulo(%d, %d) = %d; want %d", test.a, test.b, result, test.expected)
+			}
+		}
+	}
+}
+
+func TestCalculatorModulo(t *testing.T) {
+	calc := NewCalculator()
+
+	result, err := calc.Modulo(10, 4)
+	if err != nil || result != 2 {
+		t.Errorf("Calculator.Modulo(10, 4) = (%d, %v); want (2, nil)", result, err)
+	}
+
+	if _, err := calc.Modulo(10, 0); err == nil {
+		t.Error("Calculator.Modulo(10, 0) expected error but got none")
+	}
+
+	history := calc.GetHistory()
+	if len(history) != 1 {
+		t.Fatalf("Calculator history length = %d; want 1", len(history))
+	}
+	if history[0].Type != "modulo" || history[0].A != 10 || history[0].B != 4 || history[0].Result != 2 {
+		t.Errorf("Modulo operation incorrect: %+v", history[0])
+	}
+}
+
+func TestCalculatorDivide(t *testing.T) {
+	calc := NewCalculator()
+	calc.Add(1, 2)
+
+	result, err := calc.Divide(5, 2)
+	if err != nil || result != 2.5 {
+		t.Errorf("Calculator.Divide(5, 2) = (%f, %v); want (2.5, nil)", result, err)
+	}
+
+	if _, err := calc.Divide(5, 0); err == nil {
+		t.Error("Calculator.Divide(5, 0) expected error but got none")
+	}
+
+	floatHistory := calc.GetFloatHistory()
+	if len(floatHistory) != 1 {
+		t.Fatalf("Calculator float history length = %d; want 1", len(floatHistory))
+	}
+	if op := floatHistory[0]; op.Type != "divide" || op.A != 5 || op.B != 2 || op.Result != 2.5 {
+		t.Errorf("Divide operation incorrect: %+v; want result 2.5, not truncated", op)
+	}
+
+	// The integer history is unaffected by float operations
+	history := calc.GetHistory()
+	if len(history) != 1 || history[0].Type != "add" {
+		t.Errorf("Calculator integer history = %+v; want only the add operation", history)
+	}
+
+	calc.ClearHistory()
+	if len(calc.GetFloatHistory()) != 0 {
+		t.Errorf("Calculator float history after clear = %d; want 0", len(calc.GetFloatHistory()))
+	}
+}
+
+func TestIntDivide(t *testing.T) {
+	tests := []struct {
+		a, b                int
+		quotient, remainder int
+		hasError            bool
+	}{
+		{7, 2, 3, 1, false},
+		{-7, 2, -3, -1, false},
+		{7, -2, -3, 1, false},
+		{-7, -2, 3, -1, false},
+		{6, 3, 2, 0, false},
+		{7, 0, 0, 0, true}, // division by zero
+	}
+
+	for _, test := range tests {
+		quotient, remainder, err := IntDivide(test.a, test.b)
+
+		if test.hasError {
+			if err == nil {
+				t.Errorf("IntDivide(%d, %d) expected error but got none", test.a, test.b)
+			}
+		} else {
+			if err != nil {
+				t.Errorf("IntDivide(%d, %d) unexpected error: %v", test.a, test.b, err)
+			}
+			if quotient != test.quotient || remainder != test.remainder {
+				t.Errorf("IntDivide(%d, %d) = (%d, %d); want (%d, %d)",
+					test.a, test.b, quotient, remainder, test.quotient, test.remainder)
+			}
+		}
+	}
+}
+
+func TestCalculatorIntDivide(t *testing.T) {
+	calc := NewCalculator()
+
+	quotient, remainder, err := calc.IntDivide(17, 5)
+	if err != nil || quotient != 3 || remainder != 2 {
+		t.Errorf("Calculator.IntDivide(17, 5) = (%d, %d, %v); want (3, 2, nil)", quotient, remainder, err)
+	}
+
+	if _, _, err := calc.IntDivide(17, 0); err == nil {
+		t.Error("Calculator.IntDivide(17, 0) expected error but got none")
+	}
+
+	history := calc.GetHistory()
+	if len(history) != 1 {
+		t.Fatalf("Calculator history length = %d; want 1", len(history))
+	}
+	if history[0].Type != "intdivide" || history[0].Result != 3 {
+		t.Errorf("IntDivide operation incorrect: %+v", history[0])
+	}
+}
+
+func TestCalculatorUndoRedo(t *testing.T) {
+	calc := NewCalculator()
+
+	if _, ok := calc.Undo(); ok {
+		t.Error("Undo on empty calculator should return false")
+	}
+	if _, ok := calc.Redo(); ok {
+		t.Error("Redo with nothing undone should return false")
+	}
+
+	calc.Add(1, 2)
+	calc.Multiply(3, 4)
+	calc.Add(5, 6)
+
+	op, ok := calc.Undo()
+	if !ok || op.Type != "add" || op.Result != 11 {
+		t.Errorf("Undo() = (%+v, %t); want add result 11", op, ok)
+	}
+	op, ok = calc.Undo()
+	if !ok || op.Type != "multiply" || op.Result != 12 {
+		t.Errorf("Undo() = (%+v, %t); want multiply result 12", op, ok)
+	}
+	if len(calc.GetHistory()) != 1 {
+		t.Errorf("history length after two undos = %d; want 1", len(calc.GetHistory()))
+	}
+
+	op, ok = calc.Redo()
+	if !ok || op.Type != "multiply" {
+		t.Errorf("Redo() = (%+v, %t); want multiply", op, ok)
+	}
+	if history := calc.GetHistory(); len(history) != 2 || history[1].Type != "multiply" {
+		t.Errorf("history after redo = %+v; want add then multiply", history)
+	}
+
+	// A fresh operation invalidates the remaining undone add
+	calc.Subtract(10, 4)
+	if op, ok := calc.Redo(); ok {
+		t.Errorf("Redo() after new operation = (%+v, true); want false", op)
+	}
+
+	history := calc.GetHistory()
+	expected := []string{"add", "multiply", "subtract"}
+	if len(history) != len(expected) {
+		t.Fatalf("history length = %d; want %d", len(history), len(expected))
+	}
+	for i, opType := range expected {
+		if history[i].Type != opType {
+			t.Errorf("history[%d].Type = %q; want %q", i, history[i].Type, opType)
+		}
+	}
+}
+
+func TestCalculatorStats(t *testing.T) {
+	calc := NewCalculator()
+
+	if stats := calc.Stats(); stats.Total != 0 || stats.ByType != nil || stats.Sum != 0 {
+		t.Errorf("Stats() on empty calculator = %+v; want zero value", stats)
+	}
+
+	calc.Add(2, 3)       // 5
+	calc.Subtract(1, 10) // -9
+	calc.Multiply(4, 5)  // 20
+	calc.Add(1, 1)       // 2
+
+	stats := calc.Stats()
+	if stats.Total != 4 {
+		t.Errorf("Stats().Total = %d; want 4", stats.Total)
+	}
+
+	expectedCounts := map[string]int{"add": 2, "subtract": 1, "multiply": 1}
+	if len(stats.ByType) != len(expectedCounts) {
+		t.Errorf("Stats().ByType = %v; want %v", stats.ByType, expectedCounts)
+	}
+	for opType, count := range expectedCounts {
+		if stats.ByType[opType] != count {
+			t.Errorf("Stats().ByType[%q] = %d; want %d", opType, stats.ByType[opType], count)
+		}
+	}
+
+	if stats.Sum != 18 || stats.Min != -9 || stats.Max != 20 {
+		t.Errorf("Stats() Sum/Min/Max = %d/%d/%d; want 18/-9/20", stats.Sum, stats.Min, stats.Max)
+	}
+}
+
+func TestGCD(t *testing.T) {
+	tests := []struct {
+		a, b, expected int
+	}{
+		{48, 18, 6},
+		{18, 48, 6},
+		{17, 5, 1},
+		{-48, 18, 6},
+		{48, -18, 6},
+		{0, 7, 7},
+		{7, 0, 7},
+		{0, 0, 0},
+	}
+
+	for _, test := range tests {
+		result := GCD(test.a, test.b)
+		if result != test.expected {
+			t.Errorf("GCD(%d, %d) = %d; want %d", test.a, test.b, result, test.expected)
+		}
+	}
+}
+
+func TestLCM(t *testing.T) {
+	tests := []struct {
+		a, b, expected int
+	}{
+		{4, 6, 12},
+		{21, 6, 42},
+		{5, 7, 35},
+		{-4, 6, 12},
+		{-4, -6, 12},
+		{0, 6, 0},
+		{4, 0, 0},
+	}
+
+	for _, test := range tests {
+		result := LCM(test.a, test.b)
+		if result != test.expected {
+			t.Errorf("LCM(%d, %d) = %d; want %d", test.a, test.b, result, test.expected)
+		}
+	}
+}
+
+func TestFactorial(t *testing.T) {
+	tests := []struct {
+		n, expected int
+		hasError    bool
+	}{
+		{0, 1, false},
+		{1, 1, false},
+		{5, 120, false},
+		{20, 2432902008176640000, false}, // largest factorial that fits in int64
+		{21, 0, true},                    // overflow
+		{-1, 0, true},                    // negative input
+	}
+
+	for _, test := range tests {
+		result, err := Factorial(test.n)
+
+		if test.hasError {
+			if err == nil {
+				t.Errorf("Factorial(%d) expected error but got none", test.n)
+			}
+		} else {
+			if err != nil {
+				t.Errorf("Factorial(%d) unexpected error: %v", test.n, err)
+			}
+			if result != test.expected {
+				t.Errorf("Factorial(%d) = %d; want %d", test.n, result, test.expected)
+			}
+		}
+	}
+}
+
+func TestCalculatorHistoryByType(t *testing.T) {
+	calc := NewCalculator()
+	calc.Add(1, 2)
+	calc.Multiply(3, 4)
+	calc.Add(5, 6)
+	calc.Subtract(9, 1)
+	calc.Multiply(2, 2)
+
+	multiplies := calc.HistoryByType("multiply")
+	if len(multiplies) != 2 {
+		t.Fatalf("HistoryByType(%q) length = %d; want 2", "multiply", len(multiplies))
+	}
+	if multiplies[0].Result != 12 || multiplies[1].Result != 4 {
+		t.Errorf("HistoryByType(%q) = %+v; want results 12 then 4", "multiply", multiplies)
+	}
+
+	if divides := calc.HistoryByType("divide"); len(divides) != 0 {
+		t.Errorf("HistoryByType(%q) = %+v; want empty", "divide", divides)
+	}
+
+	// Mutating the returned slice must not affect the calculator
+	multiplies[0].Result = -1
+	if again := calc.HistoryByType("multiply"); again[0].Result != 12 {
+		t.Errorf("HistoryByType returned shared state: got result %d after mutation; want 12", again[0].Result)
+	}
+}
+
+func TestCalculatorWithLimit(t *testing.T) {
+	const limit = 3
+	calc := NewCalculatorWithLimit(limit)
+
+	for i := 1; i <= limit+3; i++ {
+		calc.Add(i, 0)
+	}
+
+	history := calc.GetHistory()
+	if len(history) != limit {
+		t.Fatalf("history length = %d; want %d", len(history), limit)
+	}
+	for i, op := range history {
+		if expected := i + 4; op.Result != expected {
+			t.Errorf("history[%d].Result = %d; want %d", i, op.Result, expected)
+		}
+	}
+
+	// Redo also respects the limit
+	calc.Undo()
+	calc.Redo()
+	if history := calc.GetHistory(); len(history) != limit || history[limit-1].Result != 6 {
+		t.Errorf("history after undo/redo = %+v; want results 4, 5, 6", history)
+	}
+}
+
+func TestCalculatorWithLimitWraps(t *testing.T) {
+	calc := NewCalculatorWithLimit(3)
+
+	// Results 1..5 leave the ring wrapped with 3, 4, 5 in it
+	for i := 1; i <= 5; i++ {
+		calc.Add(i, 0)
+	}
+	calc.Undo()
+	calc.Subtract(10, 1)
+
+	want := []int{3, 4, 9}
+	history := calc.GetHistory()
+	if len(history) != len(want) {
+		t.Fatalf("history = %+v; want results %v", history, want)
+	}
+	for i, op := range history {
+		if op.Result != want[i] {
+			t.Errorf("history[%d].Result = %d; want %d", i, op.Result, want[i])
+		}
+	}
+
+	if last, ok := calc.Last(); !ok || last != 9 {
+		t.Errorf("Last() = (%d, %t); want (9, true)", last, ok)
+	}
+	if sum := calc.SumResults(); sum != 16 {
+		t.Errorf("SumResults() = %d; want 16", sum)
+	}
+	if stats := calc.Stats(); stats.Min != 3 || stats.Max != 9 || stats.Total != 3 {
+		t.Errorf("Stats() = %+v; want Min 3, Max 9, Total 3", stats)
+	}
+	if adds := calc.HistoryByType("add"); len(adds) != 2 || adds[0].Result != 3 || adds[1].Result != 4 {
+		t.Errorf("HistoryByType(%q) = %+v; want results 3, 4", "add", adds)
+	}
+
+	calc.ClearHistory()
+	calc.Add(7, 0)
+	if history := calc.GetHistory(); len(history) != 1 || history[0].Result != 7 {
+		t.Errorf("history after clear = %+v; want result 7", history)
+	}
+}
+
+func TestCalculatorUnlimitedByDefault(t *testing.T) {
+	for _, calc := range []*Calculator{NewCalculator(), NewCalculatorWithLimit(0)} {
+		for i := 0; i < 100; i++ {
+			calc.Add(i, i)
+		}
+		if length := len(calc.GetHistory()); length != 100 {
+			t.Errorf("history length = %d; want 100", length)
+		}
+	}
+}
+
+func TestCalculatorTimestamps(t *testing.T) {
+	fixed := time.Date(2024, 3, 15, 9, 30, 0, 0, time.UTC)
+	calc := NewCalculator()
+	calc.now = func() time.Time { return fixed }
+
+	calc.Add(2, 2)
+
+	history := calc.GetHistory()
+	if !history[0].Timestamp.Equal(fixed) {
+		t.Errorf("Timestamp = %v; want %v", history[0].Timestamp, fixed)
+	}
+
+	expected := "Calculator with 1 operations:\n  1. add(2, 2) = 4 at 2024-03-15T09:30:00Z\n"
+	if result := calc.StringWithTimestamps(); result != expected {
+		t.Errorf("StringWithTimestamps() = %q; want %q", result, expected)
+	}
+	if result := calc.String(); strings.Contains(result, "2024") {
+		t.Errorf("String() = %q; should not include timestamps", result)
+	}
+}
+
+func TestCalculatorSumAndAverage(t *testing.T) {
+	calc := NewCalculator()
+
+	if sum := calc.SumResults(); sum != 0 {
+		t.Errorf("SumResults() on empty calculator = %d; want 0", sum)
+	}
+	if avg := calc.AverageResult(); avg != 0 {
+		t.Errorf("AverageResult() on empty calculator = %f; want 0", avg)
+	}
+
+	calc.Add(1, 2) // 3
+	calc.Add(3, 4) // 7
+	calc.Add(0, 0) // 0
+	calc.Add(1, 1) // 2
+
+	if sum := calc.SumResults(); sum != 12 {
+		t.Errorf("SumResults() = %d; want 12", sum)
+	}
+	if avg := calc.AverageResult(); avg != 3 {
+		t.Errorf("AverageResult() = %f; want 3", avg)
+	}
+
+	calc.Add(0, 1) // 1, making the average fractional
+	if avg := calc.AverageResult(); avg != 2.6 {
+		t.Errorf("AverageResult() = %f; want 2.6", avg)
+	}
+}
+
+func TestAddChecked(t *testing.T) {
+	tests := []struct {
+		a, b, expected int
+		overflow       bool
+	}{
+		{2, 3, 5, false},
+		{-2, -3, -5, false},
+		{math.MaxInt, 0, math.MaxInt, false},
+		{math.MaxInt - 1, 1, math.MaxInt, false},
+		{math.MaxInt, 1, 0, true},
+		{math.MinInt, -1, 0, true},
+		{math.MinInt, math.MaxInt, -1, false},
+		{math.MinInt + 1, -1, math.MinInt, false},
+	}
+
+	for _, test := range tests {
+		result, err := AddChecked(test.a, test.b)
+
+		if test.overflow {
+			if !errors.Is(err, ErrOverflow) {
+				t.Errorf("AddChecked(%d, %d) error = %v; want ErrOverflow", test.a, test.b, err)
+			}
+		} else {
+			if err != nil {
+				t.Errorf("AddChecked(%d, %d) unexpected error: %v", test.a, test.b, err)
+			}
+			if result != test.expected {
+				t.Errorf("AddChecked(%d, %d) = %d; want %d", test.a, test.b, result, test.expected)
+			}
+		}
+	}
+}
+
+func TestMultiplyChecked(t *testing.T) {
+	tests := []struct {
+		a, b, expected int
+		overflow       bool
+	}{
+		{4, 5, 20, false},
+		{-4, 5, -20, false},
+		{0, math.MaxInt, 0, false},
+		{math.MaxInt, 1, math.MaxInt, false},
+		{math.MaxInt, -1, -math.MaxInt, false},
+		{math.MaxInt, 2, 0, true},
+		{math.MinInt, 2, 0, true},
+		{math.MinInt, -1, 0, true},
+		{-1, math.MinInt, 0, true},
+		{math.MinInt / 2, 2, math.MinInt, false},
+	}
+
+	for _, test := range tests {
+		result, err := MultiplyChecked(test.a, test.b)
+
+		if test.overflow {
+			if !errors.Is(err, ErrOverflow) {
+				t.Errorf("MultiplyChecked(%d, %d) error = %v; want ErrOverflow", test.a, test.b, err)
+			}
+		} else {
+			if err != nil {
+				t.Errorf("MultiplyChecked(%d, %d) unexpected error: %v", test.a, test.b, err)
+			}
+			if result != test.expected {
+				t.Errorf("MultiplyChecked(%d, %d) = %d; want %d", test.a, test.b, result, test.expected)
+			}
+		}
+	}
+}
+
+func TestPowerFloat(t *testing.T) {
+	tests := []struct {
+		base     float64
+		exp      int
+		expected float64
+	}{
+		{2, 3, 8},
+		{2, 0, 1},
+		{2, -2, 0.25},
+		{-2, -3, -0.125},
+		{0.5, -1, 2},
+		{0, 0, 1},
+		{0, 3, 0},
+		{0, -1, math.Inf(1)}, // zero base with negative exponent
+	}
+
+	for _, test := range tests {
+		result := PowerFloat(test.base, test.exp)
+		if result != test.expected {
+			t.Errorf("PowerFloat(%f, %d) = %f; want %f", test.base, test.exp, result, test.expected)
+		}
+	}
+}
+
+func TestPowerFloatEdgeCases(t *testing.T) {
+	negZero := math.Copysign(0, -1)
+
+	tests := []struct {
+		name     string
+		base     float64
+		exp      int
+		expected float64
+	}{
+		{"MinInt underflows to zero", 2, math.MinInt, 0},
+		{"one to MinInt", 1, math.MinInt, 1},
+		{"minus one to MinInt is even", -1, math.MinInt, 1},
+		{"minus one to MaxInt is odd", -1, math.MaxInt, -1},
+		{"half to MinInt overflows", 0.5, math.MinInt, math.Inf(1)},
+		{"zero to MinInt", 0, math.MinInt, math.Inf(1)},
+		{"negative zero, odd negative exponent", negZero, -1, math.Inf(-1)},
+		{"negative zero, odd negative exponent 3", negZero, -3, math.Inf(-1)},
+		{"negative zero, even negative exponent", negZero, -2, math.Inf(1)},
+		{"negative zero to MinInt", negZero, math.MinInt, math.Inf(1)},
+		{"negative zero, odd positive exponent", negZero, 3, negZero},
+		{"negative zero, even positive exponent", negZero, 2, 0},
+	}
+
+	for _, test := range tests {
+		result := PowerFloat(test.base, test.exp)
+		if result != test.expected || math.Signbit(result) != math.Signbit(test.expected) {
+			t.Errorf("%s: PowerFloat(%g, %d) = %g; want %g", test.name, test.base, test.exp, result, test.expected)
+		}
+
+		// Cross-check with math.Pow where float64(exp) is exact; MaxInt rounds to an even 2^63
+		if float64(test.exp) != math.Ldexp(1, 63) {
+			want := math.Pow(test.base, float64(test.exp))
+			if result != want || math.Signbit(result) != math.Signbit(want) {
+				t.Errorf("%s: PowerFloat(%g, %d) = %g; math.Pow gives %g", test.name, test.base, test.exp, result, want)
+			}
+		}
+	}
+}
+
+func TestCalculatorWriteCSV(t *testing.T) {
+	calc := NewCalculator()
+	calc.Add(5, 3)
+	calc.Subtract(2, 7)
+	calc.Multiply(4, 2)
+
+	var buf bytes.Buffer
+	if err := calc.WriteCSV(&buf); err != nil {
+		t.Fatalf("WriteCSV unexpected error: %v", err)
+	}
+
+	expected := "type,a,b,result\nadd,5,3,8\nsubtract,2,7,-5\nmultiply,4,2,8\n"
+	if buf.String() != expected {
+		t.Errorf("WriteCSV output = %q; want %q", buf.String(), expected)
+	}
+
+	buf.Reset()
+	if err := NewCalculator().WriteCSV(&buf); err != nil || buf.String() != "type,a,b,result\n" {
+		t.Errorf("WriteCSV on empty calculator = (%q, %v); want header only", buf.String(), err)
+	}
+}
+
+func TestCalculatorLastAndContinueAdd(t *testing.T) {
+	calc := NewCalculator()
+
+	if last, ok := calc.Last(); ok {
+		t.Errorf("Last() on empty calculator = (%d, true); want false", last)
+	}
+
+	calc.Add(5, 3)
+	if result := calc.ContinueAdd(2); result != 10 {
+		t.Errorf("ContinueAdd(2) after Add(5, 3) = %d; want 10", result)
+	}
+	if result := calc.ContinueAdd(-4); result != 6 {
+		t.Errorf("ContinueAdd(-4) = %d; want 6", result)
+	}
+
+	if last, ok := calc.Last(); !ok || last != 6 {
+		t.Errorf("Last() = (%d, %t); want (6, true)", last, ok)
+	}
+
+	history := calc.GetHistory()
+	if len(history) != 3 || history[1].A != 8 || history[1].B != 2 {
+		t.Errorf("history = %+v; want chained add(8, 2) recorded", history)
+	}
+
+	// With no history, chaining starts from zero
+	if result := NewCalculator().ContinueAdd(7); result != 7 {
+		t.Errorf("ContinueAdd(7) on empty calculator = %d; want 7", result)
+	}
+}
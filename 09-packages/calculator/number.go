@@ -0,0 +1,71 @@
+package calculator
+
+// Numeric is a constraint that permits any integer or floating-point type.
+type Numeric interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// NumberOperation represents a single arithmetic operation on type T.
+type NumberOperation[T Numeric] struct {
+	Type   string // "add", "subtract", "multiply"
+	A, B   T      // operands
+	Result T      // result
+}
+
+// NumberCalculator provides arithmetic with history tracking for any numeric type.
+// Unlike Calculator, a NumberCalculator[float64] records results without truncation.
+type NumberCalculator[T Numeric] struct {
+	history []NumberOperation[T]
+}
+
+// NewNumberCalculator creates a new NumberCalculator for type T.
+func NewNumberCalculator[T Numeric]() *NumberCalculator[T] {
+	return &NumberCalculator[T]{
+		history: make([]NumberOperation[T], 0),
+	}
+}
+
+// Add performs addition and records the operation in history.
+func (c *NumberCalculator[T]) Add(a, b T) T {
+	result := a + b
+	c.recordOperation("add", a, b, result)
+	return result
+}
+
+// Subtract performs subtraction and records the operation in history.
+func (c *NumberCalculator[T]) Subtract(a, b T) T {
+	result := a - b
+	c.recordOperation("subtract", a, b, result)
+	return result
+}
+
+// Multiply performs multiplication and records the operation in history.
+func (c *NumberCalculator[T]) Multiply(a, b T) T {
+	result := a * b
+	c.recordOperation("multiply", a, b, result)
+	return result
+}
+
+// GetHistory returns a copy of the operation history.
+func (c *NumberCalculator[T]) GetHistory() []NumberOperation[T] {
+	historyCopy := make([]NumberOperation[T], len(c.history))
+	copy(historyCopy, c.history)
+	return historyCopy
+}
+
+// ClearHistory clears the operation history.
+func (c *NumberCalculator[T]) ClearHistory() {
+	c.history = c.history[:0]
+}
+
+// recordOperation records an operation in the history.
+func (c *NumberCalculator[T]) recordOperation(op string, a, b, result T) {
+	c.history = append(c.history, NumberOperation[T]{
+		Type:   op,
+		A:      a,
+		B:      b,
+		Result: result,
+	})
+}
@@ -0,0 +1,53 @@
+package calculator
+
+import "testing"
+
+func TestNumberCalculatorInt(t *testing.T) {
+	calc := NewNumberCalculator[int]()
+
+	if result := calc.Add(5, 3); result != 8 {
+		t.Errorf("NumberCalculator[int].Add(5, 3) = %d; want 8", result)
+	}
+	if result := calc.Subtract(5, 8); result != -3 {
+		t.Errorf("NumberCalculator[int].Subtract(5, 8) = %d; want -3", result)
+	}
+	if result := calc.Multiply(4, 6); result != 24 {
+		t.Errorf("NumberCalculator[int].Multiply(4, 6) = %d; want 24", result)
+	}
+
+	history := calc.GetHistory()
+	if len(history) != 3 {
+		t.Fatalf("NumberCalculator[int] history length = %d; want 3", len(history))
+	}
+	if history[2].Type != "multiply" || history[2].Result != 24 {
+		t.Errorf("Third operation incorrect: %+v", history[2])
+	}
+
+	calc.ClearHistory()
+	if len(calc.GetHistory()) != 0 {
+		t.Errorf("NumberCalculator[int] history after clear = %d; want 0", len(calc.GetHistory()))
+	}
+}
+
+func TestNumberCalculatorFloat64(t *testing.T) {
+	calc := NewNumberCalculator[float64]()
+
+	if result := calc.Add(0.5, 0.25); result != 0.75 {
+		t.Errorf("NumberCalculator[float64].Add(0.5, 0.25) = %f; want 0.75", result)
+	}
+	if result := calc.Multiply(2.5, 2); result != 5 {
+		t.Errorf("NumberCalculator[float64].Multiply(2.5, 2) = %f; want 5", result)
+	}
+	if result := calc.Subtract(1, 1.5); result != -0.5 {
+		t.Errorf("NumberCalculator[float64].Subtract(1, 1.5) = %f; want -0.5", result)
+	}
+
+	// Fractional results are recorded without truncation
+	history := calc.GetHistory()
+	if len(history) != 3 {
+		t.Fatalf("NumberCalculator[float64] history length = %d; want 3", len(history))
+	}
+	if history[0].Result != 0.75 || history[2].Result != -0.5 {
+		t.Errorf("NumberCalculator[float64] history = %+v; want untruncated results", history)
+	}
+}